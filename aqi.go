@@ -1,50 +1,93 @@
 package goaqi
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
+	"math"
 )
 
 type Var struct {
 	P     Pollutant
 	Value float64 // only co need float, other need int only
+
+	// Unit of Value. If unspecified, Value is treated as already in the unit
+	// expected by the standard, see [Standard.ExpectedUnit].
+	Unit Unit
+
+	// Condition the gas pollutant is measured at, like the actual state of a
+	// station. If nil, Value is treated as measured at 25 °C and 1 atm.
+	Condition *Condition
+}
+
+// ValueIn returns Value converted to unit u.
+//
+// If Condition is set, gas pollutants are also converted to
+// ReferenceCondition. Particulate matter stays at the actual state as
+// required by GB 3095-2012.
+func (v *Var) ValueIn(u Unit) (float64, error) {
+	if _, ok := molecularWeight[v.P]; ok && v.Condition != nil {
+		return ConvertUnitAt(v.P, v.Value, v.Unit, u, *v.Condition, ReferenceCondition)
+	}
+	return ConvertUnit(v.P, v.Value, v.Unit, u)
+}
+
+// ConvertTo converts Value to unit u in place.
+func (v *Var) ConvertTo(u Unit) (*Var, error) {
+	value, err := v.ValueIn(u)
+	if err != nil {
+		return nil, err
+	}
+	v.Value = value
+	v.Unit = u
+	if _, ok := molecularWeight[v.P]; ok && v.Condition != nil {
+		v.Condition = nil
+	}
+	return v, nil
 }
 
 func (v *Var) MiuGPerM3ToPPM() *Var {
 	v.Value = MiuGPerM3ToMgPerM3(v.Value)
 	v.Value = MgPerM3ToPPM(v.P, v.Value)
+	v.Unit = UNIT_PPM
 	return v
 }
 
 func (v *Var) MiuGPerM3ToPPB() *Var {
-	v.Value = MiuGPerM3ToMgPerM3(v.Value)
-	v.Value = MgPerM3ToPPM(v.P, v.Value)
+	v.MiuGPerM3ToPPM()
+	v.Value = PPMToPPB(v.Value)
+	v.Unit = UNIT_PPB
 	return v
 }
 
 func (v *Var) MgPerM3ToPPM() *Var {
 	v.Value = MgPerM3ToPPM(v.P, v.Value)
+	v.Unit = UNIT_PPM
 	return v
 }
 
 func (v *Var) PPMToMgPerM3() *Var {
 	v.Value = PPMToMgPerM3(v.P, v.Value)
+	v.Unit = UNIT_MG_PER_M3
 	return v
 }
 
 func (v *Var) MgPerM3ToPPB() *Var {
 	v.MgPerM3ToPPM()
 	v.Value = PPMToPPB(v.Value)
+	v.Unit = UNIT_PPB
 	return v
 }
 
 func (v *Var) PPMToPPB() *Var {
 	v.Value = PPMToPPB(v.Value)
+	v.Unit = UNIT_PPB
 	return v
 }
 
 func (v *Var) PPBToPPM() *Var {
 	v.Value = PPBToPPM(v.Value)
+	v.Unit = UNIT_PPM
 	return v
 }
 
@@ -58,8 +101,15 @@ type Standard interface {
 	// Like `epa` or `mep`
 	Name() string
 
-	// Calc returns multiple pollutants indicating parallel primary pollutants.
+	// Calc returns multiple pollutants indicating parallel primary pollutants,
+	// ordered by input order, see [PrimaryPollutants].
+	//
+	// Vars with a Unit are converted to the unit from ExpectedUnit first.
 	Calc(pollutantVars ...*Var) (int, []Pollutant, error)
+
+	// ExpectedUnit returns the unit the standard's breakpoints are defined in,
+	// or UNIT_UNSPECIFIED if p is not supported.
+	ExpectedUnit(p Pollutant) Unit
 }
 
 type StandardWithColor interface {
@@ -67,20 +117,50 @@ type StandardWithColor interface {
 	AQIToColor(aqi int) (*color.RGBA, error)
 }
 
+// Scale is the range of index returned by Standard.Calc.
+type Scale struct {
+	Min int
+	Max int
+
+	// Categorical is true if Calc returns the number of a band, from Min for
+	// the best band to Max for the worst, rather than a value on a
+	// continuous scale like 0-500.
+	Categorical bool
+}
+
+type StandardWithScale interface {
+	Standard
+	Scale() Scale
+}
+
+// GetRanges returns the segment of breakpoints where value lies, with the
+// same semantics as Table.Lookup of NewTable(pIndexRange, aqiIndexRange).
 func GetRanges(value float64, pIndexRange []float64, aqiIndexRange []float64) (iaqiLo, iaqiHi, pLo, pHi float64, err error) {
-	for i, v := range pIndexRange {
-		if i == len(pIndexRange)-1 {
-			return aqiIndexRange[i-1], aqiIndexRange[i], pIndexRange[i-1], v, nil
-		}
-		if pIndexRange[i] < value && value <= pIndexRange[i+1] {
-			return aqiIndexRange[i], aqiIndexRange[i+1], v, pIndexRange[i+1], nil
-		}
+	breakpoint, err := NewTable(pIndexRange, aqiIndexRange).Lookup(value)
+	if err != nil {
+		return 0, 0, 0, 0, err
 	}
-	return 0, 0, 0, 0, fmt.Errorf("go-aqi: bad range value=%+v for pIndexRange=%+v", value, pIndexRange)
+	return breakpoint.ILo, breakpoint.IHi, breakpoint.CLo, breakpoint.CHi, nil
 }
 
 func CalcViaHiLo(value, iaqiLo, iaqiHi, pLo, pHi float64) (int, error) {
-	return int((iaqiHi-iaqiLo)/(pHi-pLo)*(value-pLo) + iaqiLo), nil
+	return int(CalcRawViaHiLo(value, iaqiLo, iaqiHi, pLo, pHi)), nil
+}
+
+// CalcRawViaHiLo is like CalcViaHiLo, but returns the unrounded index.
+func CalcRawViaHiLo(value, iaqiLo, iaqiHi, pLo, pHi float64) float64 {
+	return (iaqiHi-iaqiLo)/(pHi-pLo)*(value-pLo) + iaqiLo
+}
+
+// CalcInverseViaHiLo is the inverse of CalcRawViaHiLo, returning the
+// concentration of aqi in the row [pLo, pHi] to [iaqiLo, iaqiHi]. Table.Inverse
+// finds the row.
+func CalcInverseViaHiLo(aqi, iaqiLo, iaqiHi, pLo, pHi float64) float64 {
+	// Keep breakpoints exact.
+	if aqi == iaqiHi {
+		return pHi
+	}
+	return (pHi-pLo)/(iaqiHi-iaqiLo)*(aqi-iaqiLo) + pLo
 }
 
 // https://teesing.com/en/library/tools/ppm-mg3-converter
@@ -90,10 +170,60 @@ var molecularWeight = map[Pollutant]float64{
 	CO_24H:  28.01,
 	NO2_1H:  46.01,
 	NO2_24H: 46.01,
+	NO2_3H:  46.01,
 	O3_1H:   48,
 	O3_8H:   48,
+	O3_3H:   48,
 	SO2_1H:  64.06,
 	SO2_24H: 64.06,
+	SO2_15M: 64.06,
+	SO2_3H:  64.06,
+	NH3_24H: 17.03,
+}
+
+// referenceMolarVolume is the molar volume in L/mol at 25 °C and 1 atm used
+// by PPMToMgPerM3 and MgPerM3ToPPM.
+const referenceMolarVolume = 24.45
+
+// ErrInvalidCondition is returned for conditions at or below absolute zero or
+// without positive pressure.
+var ErrInvalidCondition = errors.New("go-aqi: invalid condition")
+
+// Condition is the temperature and pressure a concentration is measured at.
+type Condition struct {
+	Temperature float64 // °C
+	Pressure    float64 // kPa
+}
+
+var (
+	// ReferenceCondition is 25 °C at 1 atm, the reference state of gas
+	// pollutants in EPA standard and GB 3095-2012 after its 2018 amendment.
+	ReferenceCondition = Condition{Temperature: 25, Pressure: 101.325}
+
+	// StandardCondition is 0 °C at 1 atm, the standard state used by
+	// GB 3095-2012 before its 2018 amendment.
+	StandardCondition = Condition{Temperature: 0, Pressure: 101.325}
+)
+
+// Validate returns ErrInvalidCondition if c is not a physical state.
+func (c Condition) Validate() error {
+	if !(c.Pressure > 0) || !(c.Temperature > -273.15) {
+		return fmt.Errorf("%w: %+v", ErrInvalidCondition, c)
+	}
+	return nil
+}
+
+// MolarVolume returns the molar volume of ideal gas in L/mol, scaled from the
+// conventional 24.45 of ConvertUnit at ReferenceCondition, so conversions at
+// ReferenceCondition match those without condition. It is NaN if c is not
+// valid, see Validate.
+func (c Condition) MolarVolume() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return referenceMolarVolume *
+		(c.Temperature + 273.15) / (ReferenceCondition.Temperature + 273.15) *
+		ReferenceCondition.Pressure / c.Pressure
 }
 
 func PPMToPPB(value float64) float64 {
@@ -117,7 +247,25 @@ func MgPerM3ToPPM(p Pollutant, value float64) float64 {
 	if !ok {
 		return value
 	}
-	return 24.45 * value / v
+	return referenceMolarVolume * value / v
+}
+
+// PPMToMgPerM3At is like PPMToMgPerM3 but at condition c.
+func PPMToMgPerM3At(p Pollutant, value float64, c Condition) float64 {
+	v, ok := molecularWeight[p]
+	if !ok {
+		return value
+	}
+	return value * v / c.MolarVolume()
+}
+
+// MgPerM3ToPPMAt is like MgPerM3ToPPM but at condition c.
+func MgPerM3ToPPMAt(p Pollutant, value float64, c Condition) float64 {
+	v, ok := molecularWeight[p]
+	if !ok {
+		return value
+	}
+	return c.MolarVolume() * value / v
 }
 
 func MiuGPerM3ToMgPerM3(v float64) float64 {
@@ -127,3 +275,78 @@ func MiuGPerM3ToMgPerM3(v float64) float64 {
 func MgGPerM3ToMiuGPerM3(v float64) float64 {
 	return v * 1000
 }
+
+// ConvertUnit converts value of pollutant p between units at 25 °C and 1 atm.
+//
+// Conversion between mass concentration and ppm/ppb needs the molecular weight
+// of p, so it fails for particulate matter. If either unit is unspecified
+// value is returned as is.
+func ConvertUnit(p Pollutant, value float64, from, to Unit) (float64, error) {
+	return convertUnit(p, value, from, to, referenceMolarVolume, referenceMolarVolume)
+}
+
+// ConvertUnitAt is like ConvertUnit, but value is measured at condition
+// fromCondition and the result is for condition toCondition.
+//
+// Mass concentrations scale with the molar volume of air, while ppm/ppb do
+// not depend on temperature and pressure. ErrInvalidCondition is returned if
+// either condition is not valid.
+func ConvertUnitAt(p Pollutant, value float64, from, to Unit, fromCondition, toCondition Condition) (float64, error) {
+	if err := fromCondition.Validate(); err != nil {
+		return 0, err
+	}
+	if err := toCondition.Validate(); err != nil {
+		return 0, err
+	}
+	return convertUnit(p, value, from, to, fromCondition.MolarVolume(), toCondition.MolarVolume())
+}
+
+func isMassUnit(u Unit) bool {
+	return u == UNIT_MIU_G_PER_M3 || u == UNIT_MG_PER_M3
+}
+
+func convertUnit(p Pollutant, value float64, from, to Unit, fromMolarVolume, toMolarVolume float64) (float64, error) {
+	if from == UNIT_UNSPECIFIED || to == UNIT_UNSPECIFIED {
+		return value, nil
+	}
+	if from == to && (!isMassUnit(from) || fromMolarVolume == toMolarVolume) {
+		return value, nil
+	}
+
+	// Normalize to mg/m3 or ppm.
+	switch from {
+	case UNIT_MIU_G_PER_M3:
+		value = MiuGPerM3ToMgPerM3(value)
+	case UNIT_MG_PER_M3:
+	case UNIT_PPM:
+	case UNIT_PPB:
+		value = PPBToPPM(value)
+	default:
+		return 0, fmt.Errorf("go-aqi: unknown unit %v", from)
+	}
+	if isMassUnit(from) != isMassUnit(to) {
+		if _, ok := molecularWeight[p]; !ok {
+			return 0, fmt.Errorf("go-aqi: can not convert %v from %v to %v without molecular weight", p, from, to)
+		}
+	}
+
+	switch {
+	case isMassUnit(from) && isMassUnit(to):
+		value = value * fromMolarVolume / toMolarVolume
+	case isMassUnit(from):
+		value = value * fromMolarVolume / molecularWeight[p]
+	case isMassUnit(to):
+		value = value * molecularWeight[p] / toMolarVolume
+	}
+
+	switch to {
+	case UNIT_MIU_G_PER_M3:
+		return MgGPerM3ToMiuGPerM3(value), nil
+	case UNIT_MG_PER_M3, UNIT_PPM:
+		return value, nil
+	case UNIT_PPB:
+		return PPMToPPB(value), nil
+	default:
+		return 0, fmt.Errorf("go-aqi: unknown unit %v", to)
+	}
+}
@@ -0,0 +1,133 @@
+package goaqi_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+// standardMolarVolume is the molar volume at StandardCondition scaled from
+// 24.45 at ReferenceCondition.
+const standardMolarVolume = 24.45 * 273.15 / 298.15
+
+func TestConvertUnitAt(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             goaqi.Pollutant
+		value         float64
+		from, to      goaqi.Unit
+		fromCondition goaqi.Condition
+		toCondition   goaqi.Condition
+		want          float64
+		wantErr       error
+	}{
+		{
+			name: "same as ConvertUnit at reference", p: goaqi.CO_8H, value: 1,
+			from: goaqi.UNIT_PPM, to: goaqi.UNIT_MG_PER_M3,
+			fromCondition: goaqi.ReferenceCondition, toCondition: goaqi.ReferenceCondition,
+			want: 28.01 / 24.45,
+		},
+		{
+			name: "standard to reference", p: goaqi.SO2_24H, value: 100,
+			from: goaqi.UNIT_MIU_G_PER_M3, to: goaqi.UNIT_MIU_G_PER_M3,
+			fromCondition: goaqi.StandardCondition, toCondition: goaqi.ReferenceCondition,
+			want: 100 * 273.15 / 298.15,
+		},
+		{
+			name: "continuous near reference", p: goaqi.SO2_24H, value: 100,
+			from: goaqi.UNIT_MIU_G_PER_M3, to: goaqi.UNIT_MIU_G_PER_M3,
+			fromCondition: goaqi.Condition{Temperature: 25.001, Pressure: 101.325}, toCondition: goaqi.ReferenceCondition,
+			want: 100 * 298.151 / 298.15,
+		},
+		{
+			name: "ppm does not depend on condition", p: goaqi.O3_8H, value: 1,
+			from: goaqi.UNIT_PPM, to: goaqi.UNIT_PPB,
+			fromCondition: goaqi.StandardCondition, toCondition: goaqi.ReferenceCondition,
+			want: 1000,
+		},
+		{
+			name: "zero condition", p: goaqi.SO2_24H, value: 100,
+			from: goaqi.UNIT_MIU_G_PER_M3, to: goaqi.UNIT_MIU_G_PER_M3,
+			fromCondition: goaqi.Condition{}, toCondition: goaqi.ReferenceCondition,
+			wantErr: goaqi.ErrInvalidCondition,
+		},
+		{
+			name: "absolute zero", p: goaqi.SO2_24H, value: 100,
+			from: goaqi.UNIT_MIU_G_PER_M3, to: goaqi.UNIT_MIU_G_PER_M3,
+			fromCondition: goaqi.ReferenceCondition, toCondition: goaqi.Condition{Temperature: -273.15, Pressure: 101.325},
+			wantErr: goaqi.ErrInvalidCondition,
+		},
+		{
+			name: "negative pressure", p: goaqi.O3_8H, value: 1,
+			from: goaqi.UNIT_PPM, to: goaqi.UNIT_PPB,
+			fromCondition: goaqi.Condition{Temperature: 25, Pressure: -1}, toCondition: goaqi.ReferenceCondition,
+			wantErr: goaqi.ErrInvalidCondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := goaqi.ConvertUnitAt(tt.p, tt.value, tt.from, tt.to, tt.fromCondition, tt.toCondition)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConvertUnitAt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ConvertUnitAt() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPPMToMgPerM3At(t *testing.T) {
+	tests := []struct {
+		name string
+		p    goaqi.Pollutant
+		c    goaqi.Condition
+		want float64
+	}{
+		{name: "reference", p: goaqi.CO_8H, c: goaqi.ReferenceCondition, want: 28.01 / 24.45},
+		{name: "standard", p: goaqi.CO_8H, c: goaqi.StandardCondition, want: 28.01 / standardMolarVolume},
+		{name: "particulate matter", p: goaqi.PM2_5_24H, c: goaqi.StandardCondition, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goaqi.PPMToMgPerM3At(tt.p, 1, tt.c); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("PPMToMgPerM3At() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := goaqi.PPMToMgPerM3At(goaqi.CO_8H, 1, goaqi.Condition{}); !math.IsNaN(got) {
+		t.Errorf("PPMToMgPerM3At() got = %v for zero condition, want NaN", got)
+	}
+}
+
+func TestMgPerM3ToPPMAt(t *testing.T) {
+	tests := []struct {
+		name string
+		p    goaqi.Pollutant
+		c    goaqi.Condition
+		want float64
+	}{
+		{name: "reference", p: goaqi.O3_8H, c: goaqi.ReferenceCondition, want: 24.45},
+		{name: "standard", p: goaqi.O3_8H, c: goaqi.StandardCondition, want: standardMolarVolume},
+		{name: "particulate matter", p: goaqi.PM10_24H, c: goaqi.StandardCondition, want: 48},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goaqi.MgPerM3ToPPMAt(tt.p, 48, tt.c); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("MgPerM3ToPPMAt() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := goaqi.MgPerM3ToPPMAt(goaqi.O3_8H, 48, goaqi.Condition{}); !math.IsNaN(got) {
+		t.Errorf("MgPerM3ToPPMAt() got = %v for zero condition, want NaN", got)
+	}
+}
+
+func TestVar_ValueIn_invalidCondition(t *testing.T) {
+	v := &goaqi.Var{P: goaqi.SO2_24H, Value: 100, Unit: goaqi.UNIT_MIU_G_PER_M3, Condition: &goaqi.Condition{}}
+	if _, err := v.ValueIn(goaqi.UNIT_PPB); !errors.Is(err, goaqi.ErrInvalidCondition) {
+		t.Errorf("ValueIn() error = %v, want %v", err, goaqi.ErrInvalidCondition)
+	}
+}
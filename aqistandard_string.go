@@ -11,11 +11,22 @@ func _() {
 	_ = x[AQISTANDARD_UNSPECIFIED-0]
 	_ = x[AQISTANDARD_US-1]
 	_ = x[AQISTANDARD_CN-2]
+	_ = x[AQISTANDARD_IN-3]
+	_ = x[AQISTANDARD_EU-4]
+	_ = x[AQISTANDARD_UK-5]
+	_ = x[AQISTANDARD_CA-6]
+	_ = x[AQISTANDARD_HK-7]
+	_ = x[AQISTANDARD_SG-8]
+	_ = x[AQISTANDARD_KR-9]
+	_ = x[AQISTANDARD_MX-10]
+	_ = x[AQISTANDARD_AU-11]
+	_ = x[AQISTANDARD_TW-12]
+	_ = x[AQISTANDARD_CAQI-13]
 }
 
-const _AQIStandard_name = "AQISTANDARD_UNSPECIFIEDAQISTANDARD_USAQISTANDARD_CN"
+const _AQIStandard_name = "AQISTANDARD_UNSPECIFIEDAQISTANDARD_USAQISTANDARD_CNAQISTANDARD_INAQISTANDARD_EUAQISTANDARD_UKAQISTANDARD_CAAQISTANDARD_HKAQISTANDARD_SGAQISTANDARD_KRAQISTANDARD_MXAQISTANDARD_AUAQISTANDARD_TWAQISTANDARD_CAQI"
 
-var _AQIStandard_index = [...]uint8{0, 23, 37, 51}
+var _AQIStandard_index = [...]uint8{0, 23, 37, 51, 65, 79, 93, 107, 121, 135, 149, 163, 177, 191, 207}
 
 func (i AQIStandard) String() string {
 	if i < 0 || i >= AQIStandard(len(_AQIStandard_index)-1) {
@@ -0,0 +1,193 @@
+// Package australia is for Air Quality Categories of Australia
+//
+// The categories are used by NSW and other states, where the index of each
+// pollutant is its concentration as a percentage of the National Environment
+// Protection (Ambient Air Quality) Measure (NEPM) standard. Visibility measured
+// by nephelometer is an optional input.
+//
+// Offical Doc
+// https://www.airquality.nsw.gov.au/health-advice/understanding-air-quality-data
+package australia
+
+import (
+	"fmt"
+	"image/color"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_AU
+
+// standards are NEPM standards, an index of 100 means the concentration is
+// at the standard.
+var standards = map[goaqi.Pollutant]float64{
+	goaqi.O3_1H:     0.08,  // ppm
+	goaqi.O3_8H:     0.065, // ppm
+	goaqi.NO2_1H:    0.08,  // ppm
+	goaqi.SO2_1H:    0.10,  // ppm
+	goaqi.CO_8H:     9.0,   // ppm
+	goaqi.PM10_24H:  50,    // μg/m3
+	goaqi.PM2_5_24H: 25,    // μg/m3
+	goaqi.NEPH_1H:   2.1,   // 10^-4 m^-1
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.O3_1H:     goaqi.UNIT_PPM,
+	goaqi.O3_8H:     goaqi.UNIT_PPM,
+	goaqi.NO2_1H:    goaqi.UNIT_PPM,
+	goaqi.SO2_1H:    goaqi.UNIT_PPM,
+	goaqi.CO_8H:     goaqi.UNIT_PPM,
+	goaqi.PM10_24H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM2_5_24H: goaqi.UNIT_MIU_G_PER_M3,
+}
+
+// Windows are rolling averages of NEPM reporting, where 8-hour values need
+// 6 hours and daily PM 18 hours of data.
+var Windows = []goaqi.Window{
+	{From: goaqi.O3_1H, To: goaqi.O3_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.CO_1H, To: goaqi.CO_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.PM10_1H, To: goaqi.PM10_24H, Hours: 24, MinValid: 18},
+	{From: goaqi.PM2_5_1H, To: goaqi.PM2_5_24H, Hours: 24, MinValid: 18},
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+	LEVEL5
+	LEVEL6
+)
+
+// levelToColor and levelToDesc are the NSW categories for the bands of
+// percentage of NEPM standard, where 100 is the lowest index of Poor.
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 49, G: 173, B: 211},
+	LEVEL2: {R: 153, G: 185, B: 100},
+	LEVEL3: {R: 255, G: 210, B: 54},
+	LEVEL4: {R: 236, G: 120, B: 58},
+	LEVEL5: {R: 120, G: 45, B: 73},
+	LEVEL6: {R: 208, G: 71, B: 48},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Very good",
+	LEVEL2: "Good",
+	LEVEL3: "Fair",
+	LEVEL4: "Poor",
+	LEVEL5: "Very poor",
+	LEVEL6: "Hazardous",
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "australia"
+}
+
+// Calc is func for index computing, which returns the highest percentage of
+// NEPM standard of all pollutants. The index is not capped.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns index of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		standard, ok := standards[pollutantVar.P]
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+		if err := goaqi.ValidateValue(value); err != nil {
+			return nil, err
+		}
+
+		rawAQI := value / standard * 100
+		iaqi.Value, iaqi.RawValue, iaqi.Skipped = goaqi.RoundDown(rawAQI), rawAQI, false
+		iaqi.Breakpoint = &goaqi.Breakpoint{CLo: 0, CHi: standard, ILo: 0, IHi: 100}
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundDown, 33, nil), nil
+}
+
+// Scale returns the range of index, where Max is the lowest index of
+// Hazardous. Calc may return higher values.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 0, Max: 200}
+}
+
+// ExpectedUnit returns the unit of standards for p. Visibility is in 10^-4
+// m^-1, which has no Unit, so it is UNIT_UNSPECIFIED.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
+}
+
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= 33 {
+		return LEVEL1
+	}
+	if aqi <= 66 {
+		return LEVEL2
+	}
+	if aqi <= 99 {
+		return LEVEL3
+	}
+	if aqi <= 149 {
+		return LEVEL4
+	}
+	if aqi <= 199 {
+		return LEVEL5
+	}
+	return LEVEL6
+}
+
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
+
+// AQIToConcentration returns the concentration of p, in the unit from
+// ExpectedUnit, whose index is aqi.
+func (a *Algo) AQIToConcentration(p goaqi.Pollutant, aqi int) (float64, error) {
+	standard, ok := standards[p]
+	if !ok {
+		return 0, fmt.Errorf("go-aqi: unsupported pollutant %v", p)
+	}
+	return float64(aqi) / 100 * standard, nil
+}
@@ -0,0 +1,177 @@
+package australia_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/australia"
+)
+
+var (
+	_ goaqi.StandardWithColor   = &australia.Algo{}
+	_ goaqi.StandardWithDetail  = &australia.Algo{}
+	_ goaqi.StandardWithScale   = &australia.Algo{}
+	_ goaqi.StandardWithInverse = &australia.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	algo := &australia.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.PM2_5_24H,
+			Value: 20,
+		},
+		{
+			P:     goaqi.NEPH_1H,
+			Value: 1.05,
+		},
+	}
+	aqi, primaryPollutants, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(aqi)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("index=%v as %v with primary pollutant as %v\n", aqi, desc, primaryPollutants)
+	// Output: index=80 as Fair with primary pollutant as [PM2_5_24H]
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr bool
+	}{
+		{
+			name: "very good",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 5},
+				},
+			},
+			want: 20,
+		},
+		{
+			name: "visibility",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 20},
+					{P: goaqi.NEPH_1H, Value: 2.1},
+				},
+			},
+			want:  100,
+			want1: []goaqi.Pollutant{goaqi.NEPH_1H},
+		},
+		{
+			name: "converted from ppb",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_1H, Value: 40, Unit: goaqi.UNIT_PPB},
+				},
+			},
+			want:  50,
+			want1: []goaqi.Pollutant{goaqi.O3_1H},
+		},
+		{
+			name: "not capped",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 150},
+				},
+			},
+			want:  300,
+			want1: []goaqi.Pollutant{goaqi.PM10_24H},
+		},
+		{
+			name: "NaN value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: math.NaN()},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: -1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &australia.Algo{}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("australia.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("australia.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("australia.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestAlgo_AQIToDesc(t *testing.T) {
+	tests := []struct {
+		aqi  int
+		want string
+	}{
+		{aqi: 33, want: "Very good"},
+		{aqi: 66, want: "Good"},
+		{aqi: 99, want: "Fair"},
+		{aqi: 100, want: "Poor"},
+		{aqi: 150, want: "Very poor"},
+		{aqi: 200, want: "Hazardous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			a := &australia.Algo{}
+			got, err := a.AQIToDesc(tt.aqi)
+			if err != nil {
+				t.Fatalf("australia.AQIToDesc() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("australia.AQIToDesc(%v) got = %v, want %v", tt.aqi, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.PM2_5_24H, Value: 5},
+		unsupported,
+	}
+
+	if _, err := (&australia.Algo{}).CalcWithDetail(inputs...); err != nil {
+		t.Fatalf("australia.CalcWithDetail() error = %v", err)
+	}
+	_, err := (&australia.Algo{Strict: true}).CalcWithDetail(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("australia.CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,173 @@
+package goaqi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Window is the moving average of an hourly pollutant.
+type Window struct {
+	From     Pollutant // hourly pollutant to average, like O3_1H
+	To       Pollutant // averaged pollutant, like O3_8H
+	Hours    int       // length of window
+	MinValid int       // minimum valid hours in window
+}
+
+// MovingAverage returns the average of w.From for w.Hours hours ending at the
+// hour of end, as a Var of w.To in the unit of the most recent observation.
+//
+// ErrInsufficientData is returned if less than w.MinValid hours are valid. An
+// error is returned if only some observations in the window have a Unit, as
+// the others can not be converted to it.
+func MovingAverage(w Window, end time.Time, observations ...*Observation) (*Var, error) {
+	var (
+		unit                   = UNIT_UNSPECIFIED
+		latest                 time.Time
+		specified, unspecified bool
+	)
+	hour := end.Truncate(time.Hour)
+	for _, observation := range observations {
+		i := int(hour.Sub(observation.Time.Truncate(time.Hour)) / time.Hour)
+		if observation.P != w.From || i < 0 || i >= w.Hours {
+			continue
+		}
+		if observation.Unit == UNIT_UNSPECIFIED {
+			unspecified = true
+		} else {
+			specified = true
+		}
+		if !observation.Time.Before(latest) {
+			unit, latest = observation.Unit, observation.Time
+		}
+	}
+	if specified && unspecified {
+		return nil, fmt.Errorf("go-aqi: mixed specified and unspecified units of %v at %v", w.From, end)
+	}
+	values, err := HourlyValues(observations, w.From, unit, end, w.Hours)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sum   float64
+		valid int
+	)
+	for _, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		sum += value
+		valid++
+	}
+	if valid == 0 || valid < w.MinValid {
+		return nil, fmt.Errorf("go-aqi: %v of %v valid hours for %v at %v: %w", valid, w.Hours, w.To, end, ErrInsufficientData)
+	}
+	return &Var{P: w.To, Value: sum / float64(valid), Unit: unit}, nil
+}
+
+// Averager keeps hourly observations to compute moving averages of Windows.
+type Averager struct {
+	Windows []Window
+
+	observations []*Observation
+}
+
+func NewAverager(windows ...Window) *Averager {
+	return &Averager{Windows: windows}
+}
+
+// Add appends hourly observations, dropping ones too old for any window.
+func (a *Averager) Add(observations ...*Observation) {
+	a.observations = append(a.observations, observations...)
+
+	var (
+		latest   time.Time
+		maxHours int
+	)
+	for _, observation := range a.observations {
+		if observation.Time.After(latest) {
+			latest = observation.Time
+		}
+	}
+	for _, w := range a.Windows {
+		if w.Hours > maxHours {
+			maxHours = w.Hours
+		}
+	}
+	oldest := latest.Truncate(time.Hour).Add(-time.Duration(maxHours-1) * time.Hour)
+	kept := a.observations[:0]
+	for _, observation := range a.observations {
+		if !observation.Time.Truncate(time.Hour).Before(oldest) {
+			kept = append(kept, observation)
+		}
+	}
+	a.observations = kept
+}
+
+// At returns moving averages of every window ending at the hour of end, ready
+// to pass to Calc. Windows without enough valid hours are left out.
+func (a *Averager) At(end time.Time) ([]*Var, error) {
+	pollutantVars := make([]*Var, 0, len(a.Windows))
+	for _, w := range a.Windows {
+		pollutantVar, err := MovingAverage(w, end, a.observations...)
+		if errors.Is(err, ErrInsufficientData) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pollutantVars = append(pollutantVars, pollutantVar)
+	}
+	return pollutantVars, nil
+}
+
+// AverageFunc returns the average computed from hourly observations of p at
+// the hour of end, or nil if p is not averaged by the standard.
+type AverageFunc func(p Pollutant, end time.Time, observations ...*Observation) (*Var, error)
+
+// WindowAverage returns MovingAverage of the window of windows averaging p, or
+// nil if there is none.
+func WindowAverage(windows []Window, p Pollutant, end time.Time, observations ...*Observation) (*Var, error) {
+	for _, w := range windows {
+		if w.From == p {
+			return MovingAverage(w, end, observations...)
+		}
+	}
+	return nil, nil
+}
+
+// VarsAt returns Vars at the hour of end from hourly observations, ready to
+// pass to Calc. Observations of that hour are used as is if asIs reports their
+// pollutant, and every pollutant is also averaged by average.
+//
+// Pollutants without enough hours for their average are left out.
+func VarsAt(observations []*Observation, end time.Time, asIs func(p Pollutant) bool, average AverageFunc) ([]*Var, error) {
+	var (
+		pollutantVars = make([]*Var, 0)
+		seen          = make(map[Pollutant]bool)
+		hour          = end.Truncate(time.Hour)
+	)
+	for _, observation := range observations {
+		if asIs(observation.P) && observation.Time.Truncate(time.Hour).Equal(hour) {
+			pollutantVars = append(pollutantVars, &observation.Var)
+		}
+		if seen[observation.P] {
+			continue
+		}
+		seen[observation.P] = true
+
+		pollutantVar, err := average(observation.P, end, observations...)
+		if errors.Is(err, ErrInsufficientData) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if pollutantVar != nil {
+			pollutantVars = append(pollutantVars, pollutantVar)
+		}
+	}
+	return pollutantVars, nil
+}
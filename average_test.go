@@ -0,0 +1,65 @@
+package goaqi_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+func TestMovingAverage(t *testing.T) {
+	end := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	w := goaqi.Window{From: goaqi.O3_1H, To: goaqi.O3_8H, Hours: 8, MinValid: 6}
+	withUnit := func(u goaqi.Unit, observations []*goaqi.Observation) []*goaqi.Observation {
+		for _, observation := range observations {
+			observation.Unit = u
+		}
+		return observations
+	}
+	tests := []struct {
+		name         string
+		observations []*goaqi.Observation
+		want         *goaqi.Var
+		wantErr      bool
+	}{
+		{
+			name:         "unspecified unit",
+			observations: goaqi.HourlyObservations(goaqi.O3_1H, end, 50, 50, 50, 50, 70, 70, 70, 70),
+			want:         &goaqi.Var{P: goaqi.O3_8H, Value: 60},
+		},
+		{
+			name: "converted to unit of latest",
+			observations: append(
+				withUnit(goaqi.UNIT_PPB, goaqi.HourlyObservations(goaqi.O3_1H, end, 60, 60, 60, 60)),
+				withUnit(goaqi.UNIT_PPM, goaqi.HourlyObservations(goaqi.O3_1H, end.Add(-4*time.Hour), 0.06, 0.06, 0.06, 0.06))...,
+			),
+			want: &goaqi.Var{P: goaqi.O3_8H, Value: 60, Unit: goaqi.UNIT_PPB},
+		},
+		{
+			name: "mixed specified and unspecified units",
+			observations: append(
+				goaqi.HourlyObservations(goaqi.O3_1H, end, 0.06, 0.06, 0.06, 0.06),
+				withUnit(goaqi.UNIT_PPB, goaqi.HourlyObservations(goaqi.O3_1H, end.Add(-4*time.Hour), 60, 60, 60, 60))...,
+			),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := goaqi.MovingAverage(w, end, tt.observations...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MovingAverage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MovingAverage() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := goaqi.MovingAverage(w, end, goaqi.HourlyObservations(goaqi.O3_1H, end, 0.05)...)
+	if !errors.Is(err, goaqi.ErrInsufficientData) {
+		t.Errorf("MovingAverage() error = %v, want %v", err, goaqi.ErrInsufficientData)
+	}
+}
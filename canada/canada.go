@@ -0,0 +1,169 @@
+// Package canada is for Air Quality Health Index of Canada
+//
+// AQHI is not a breakpoint index, but the sum of excess health risk of 3-hour
+// average O3, NO2 and PM2.5, reported from 1 to 10 and 10+ above 10.
+//
+// Offical Doc
+// https://www.canada.ca/en/environment-climate-change/services/air-quality-health-index/about.html
+package canada
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_CA
+
+// AQHIPlus is the AQHI reported as 10+.
+const AQHIPlus = 11
+
+// ErrMissingPollutant is returned if any of O3, NO2 or PM2.5 is missing.
+var ErrMissingPollutant = errors.New("go-aqi: canada requires O3, NO2 and PM2.5")
+
+// coefficients of excess risk per unit concentration.
+var coefficients = map[goaqi.Pollutant]float64{
+	goaqi.NO2_3H:   0.000871, // ppb
+	goaqi.O3_3H:    0.000537, // ppb
+	goaqi.PM2_5_3H: 0.000487, // μg/m3
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.NO2_3H:   goaqi.UNIT_PPB,
+	goaqi.O3_3H:    goaqi.UNIT_PPB,
+	goaqi.PM2_5_3H: goaqi.UNIT_MIU_G_PER_M3,
+}
+
+// Windows are 3-hour averages from hourly data.
+var Windows = []goaqi.Window{
+	{From: goaqi.NO2_1H, To: goaqi.NO2_3H, Hours: 3, MinValid: 2},
+	{From: goaqi.O3_1H, To: goaqi.O3_3H, Hours: 3, MinValid: 2},
+	{From: goaqi.PM2_5_1H, To: goaqi.PM2_5_3H, Hours: 3, MinValid: 2},
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+)
+
+var aqhiToColor = map[int]*color.RGBA{
+	1:        {R: 0, G: 204, B: 255},
+	2:        {R: 0, G: 153, B: 204},
+	3:        {R: 0, G: 102, B: 153},
+	4:        {R: 255, G: 255, B: 0},
+	5:        {R: 255, G: 204, B: 0},
+	6:        {R: 255, G: 153, B: 51},
+	7:        {R: 255, G: 102, B: 102},
+	8:        {R: 255, G: 0, B: 0},
+	9:        {R: 204, G: 0, B: 0},
+	10:       {R: 153, G: 0, B: 0},
+	AQHIPlus: {R: 102, G: 0, B: 0},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Low risk",
+	LEVEL2: "Moderate risk",
+	LEVEL3: "High risk",
+	LEVEL4: "Very high risk",
+}
+
+// AQHI returns the unrounded AQHI of 3-hour average NO2 and O3 in ppb and
+// PM2.5 in μg/m3.
+func AQHI(no2, o3, pm25 float64) float64 {
+	return 1000 / 10.4 * (math.Exp(coefficients[goaqi.NO2_3H]*no2) - 1 +
+		math.Exp(coefficients[goaqi.O3_3H]*o3) - 1 +
+		math.Exp(coefficients[goaqi.PM2_5_3H]*pm25) - 1)
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "canada"
+}
+
+// Calc is func for AQHI computing from 3-hour average of O3, NO2 and PM2.5.
+// AQHI is rounded to 1 to 10, or AQHIPlus above 10. There is no primary
+// pollutant of AQHI.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	values := make(map[goaqi.Pollutant]float64)
+	var skipped []*goaqi.IAQI
+	for _, pollutantVar := range pollutantVars {
+		if _, ok := coefficients[pollutantVar.P]; !ok {
+			skipped = append(skipped, &goaqi.IAQI{Var: pollutantVar, Skipped: true, SkipReason: goaqi.SKIPREASON_UNSUPPORTED})
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return 0, nil, err
+		}
+		if err := goaqi.ValidateValue(value); err != nil {
+			return 0, nil, err
+		}
+		values[pollutantVar.P] = value
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(skipped); err != nil {
+			return 0, nil, err
+		}
+	}
+	for _, p := range []goaqi.Pollutant{goaqi.O3_3H, goaqi.NO2_3H, goaqi.PM2_5_3H} {
+		if _, ok := values[p]; !ok {
+			return 0, nil, fmt.Errorf("%w: %v", ErrMissingPollutant, p)
+		}
+	}
+
+	aqhi := int(math.Round(AQHI(values[goaqi.NO2_3H], values[goaqi.O3_3H], values[goaqi.PM2_5_3H])))
+	return min(max(aqhi, 1), AQHIPlus), nil, nil
+}
+
+// Scale returns the range of AQHI, where AQHIPlus means 10+.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 1, Max: AQHIPlus}
+}
+
+// ExpectedUnit returns the unit of p in the AQHI formula.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
+}
+
+// AQIToLevel returns the health risk category of aqhi.
+func (a *Algo) AQIToLevel(aqhi int) AQILevel {
+	if aqhi <= 3 {
+		return LEVEL1
+	}
+	if aqhi <= 6 {
+		return LEVEL2
+	}
+	if aqhi <= 10 {
+		return LEVEL3
+	}
+	return LEVEL4
+}
+
+func (a *Algo) AQIToColor(aqhi int) (*color.RGBA, error) {
+	rgba, ok := aqhiToColor[min(max(aqhi, 1), AQHIPlus)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqhi for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqhi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqhi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
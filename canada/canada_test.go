@@ -0,0 +1,169 @@
+package canada_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/canada"
+)
+
+var (
+	_ goaqi.StandardWithColor = &canada.Algo{}
+	_ goaqi.StandardWithScale = &canada.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	algo := &canada.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.NO2_3H,
+			Value: 20,
+		},
+		{
+			P:     goaqi.O3_3H,
+			Value: 30,
+		},
+		{
+			P:     goaqi.PM2_5_3H,
+			Value: 10,
+		},
+	}
+	aqhi, _, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(aqhi)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("aqhi=%v as %v\n", aqhi, desc)
+	// Output: aqhi=4 as Moderate risk
+}
+
+func ExampleAlgo_AQIToColor() {
+	algo := &canada.Algo{}
+	rgba, err := algo.AQIToColor(canada.AQHIPlus)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%v", rgba)
+	// Output: &{102 0 0 0}
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr error
+	}{
+		{
+			name: "clean air is at least 1",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 0},
+					{P: goaqi.O3_3H, Value: 0},
+					{P: goaqi.PM2_5_3H, Value: 0},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "10+",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 60},
+					{P: goaqi.O3_3H, Value: 80},
+					{P: goaqi.PM2_5_3H, Value: 100},
+				},
+			},
+			want: canada.AQHIPlus,
+		},
+		{
+			name: "convert units",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 37.6, Unit: goaqi.UNIT_MIU_G_PER_M3},
+					{P: goaqi.O3_3H, Value: 0.03, Unit: goaqi.UNIT_PPM},
+					{P: goaqi.PM2_5_3H, Value: 10},
+				},
+			},
+			want: 4,
+		},
+		{
+			name: "missing pm2.5",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 20},
+					{P: goaqi.O3_3H, Value: 30},
+					{P: goaqi.PM2_5_1H, Value: 10},
+				},
+			},
+			wantErr: canada.ErrMissingPollutant,
+		},
+		{
+			name: "NaN value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: math.NaN()},
+					{P: goaqi.O3_3H, Value: 30},
+					{P: goaqi.PM2_5_3H, Value: 10},
+				},
+			},
+			wantErr: goaqi.ErrNaNValue,
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 20},
+					{P: goaqi.O3_3H, Value: 30},
+					{P: goaqi.PM2_5_3H, Value: -1},
+				},
+			},
+			wantErr: goaqi.ErrNegativeValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &canada.Algo{}
+			got, _, err := a.Calc(tt.args.pollutantVars...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("canada.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("canada.Calc() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgo_Calc_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.O3_3H, Value: 20},
+		{P: goaqi.NO2_3H, Value: 20},
+		{P: goaqi.PM2_5_3H, Value: 10},
+		unsupported,
+	}
+
+	if _, _, err := (&canada.Algo{}).Calc(inputs...); err != nil {
+		t.Fatalf("canada.Calc() error = %v", err)
+	}
+	_, _, err := (&canada.Algo{Strict: true}).Calc(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("canada.Calc() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,215 @@
+// Package caqi is for Common Air Quality Index (CAQI) of European cities
+//
+// CAQI was developed by the CITEAIR project. It has an hourly and a daily grid,
+// each for background stations and for roadside stations, and reports a value
+// from 0 to 100 in five bands. Values above 100 are extrapolated from the
+// highest segment.
+//
+// Roadside index uses NO2, PM10, PM2.5 and CO only, so background and roadside
+// index can be computed from the same inputs.
+//
+// Offical Doc
+// https://www.airqualitynow.eu/about_indices_definition.php
+package caqi
+
+import (
+	"fmt"
+	"image/color"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_CAQI
+
+var aqiTable = []float64{0, 25, 50, 75, 100}
+
+// hourlyTables are the hourly grid.
+var hourlyTables = map[goaqi.Pollutant][]float64{
+	goaqi.NO2_1H:   {0, 50, 100, 200, 400},        // μg/m3
+	goaqi.PM10_1H:  {0, 25, 50, 90, 180},          // μg/m3
+	goaqi.PM2_5_1H: {0, 15, 30, 55, 110},          // μg/m3
+	goaqi.O3_1H:    {0, 60, 120, 180, 240},        // μg/m3
+	goaqi.CO_8H:    {0, 5000, 7500, 10000, 20000}, // μg/m3
+	goaqi.SO2_1H:   {0, 50, 100, 350, 500},        // μg/m3
+}
+
+// dailyTables are the daily grid. NO2, O3 and SO2 are the maximum hourly
+// values of the day, CO the maximum 8-hour value.
+var dailyTables = map[goaqi.Pollutant][]float64{
+	goaqi.NO2_1H:    {0, 50, 100, 200, 400},        // μg/m3
+	goaqi.PM10_24H:  {0, 15, 30, 50, 100},          // μg/m3
+	goaqi.PM2_5_24H: {0, 10, 20, 30, 60},           // μg/m3
+	goaqi.O3_1H:     {0, 60, 120, 180, 240},        // μg/m3
+	goaqi.CO_8H:     {0, 5000, 7500, 10000, 20000}, // μg/m3
+	goaqi.SO2_1H:    {0, 50, 100, 350, 500},        // μg/m3
+}
+
+// roadsidePollutants are pollutants of roadside index, others are for
+// background index only.
+var roadsidePollutants = map[goaqi.Pollutant]bool{
+	goaqi.NO2_1H:    true,
+	goaqi.PM10_1H:   true,
+	goaqi.PM10_24H:  true,
+	goaqi.PM2_5_1H:  true,
+	goaqi.PM2_5_24H: true,
+	goaqi.CO_8H:     true,
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+	LEVEL5
+)
+
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 121, G: 188, B: 106},
+	LEVEL2: {R: 187, G: 207, B: 76},
+	LEVEL3: {R: 238, G: 194, B: 11},
+	LEVEL4: {R: 242, G: 147, B: 5},
+	LEVEL5: {R: 150, G: 0, B: 24},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Very low",
+	LEVEL2: "Low",
+	LEVEL3: "Medium",
+	LEVEL4: "High",
+	LEVEL5: "Very high",
+}
+
+type Algo struct {
+	// Roadside selects the roadside index instead of the background index.
+	Roadside bool
+
+	// Daily selects the daily grid instead of the hourly grid.
+	Daily bool
+
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "caqi"
+}
+
+// Calc is func for CAQI computing with the grid selected by a.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns sub-index of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		pollutantIndexRange, ok := a.table(pollutantVar.P)
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		// Background pollutants are expected in inputs shared with background
+		// index, roadside index just leaves them out.
+		if a.Roadside && !roadsidePollutants[pollutantVar.P] {
+			iaqi.SkipReason = goaqi.SKIPREASON_NOT_APPLICABLE
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+
+		rawAQI, breakpoint, err := func() (float64, *goaqi.Breakpoint, error) {
+			// Extrapolate from the highest segment instead of capping.
+			last := len(pollutantIndexRange) - 1
+			if value > pollutantIndexRange[last] {
+				breakpoint := &goaqi.Breakpoint{
+					CLo: pollutantIndexRange[last-1],
+					CHi: pollutantIndexRange[last],
+					ILo: aqiTable[last-1],
+					IHi: aqiTable[last],
+				}
+				return goaqi.CalcRawViaHiLo(value, breakpoint.ILo, breakpoint.IHi, breakpoint.CLo, breakpoint.CHi), breakpoint, nil
+			}
+			return goaqi.CalcIAQI(value, pollutantIndexRange, aqiTable)
+		}()
+		if err != nil {
+			return nil, err
+		}
+		iaqi.Value, iaqi.RawValue, iaqi.Breakpoint, iaqi.Skipped = goaqi.RoundDown(rawAQI), rawAQI, breakpoint, false
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundDown, 25, nil), nil
+}
+
+// table returns breakpoints of p in the grid selected by a.
+func (a *Algo) table(p goaqi.Pollutant) ([]float64, bool) {
+	if a.Daily {
+		pollutantIndexRange, ok := dailyTables[p]
+		return pollutantIndexRange, ok
+	}
+	pollutantIndexRange, ok := hourlyTables[p]
+	return pollutantIndexRange, ok
+}
+
+// Scale returns the range of CAQI, where Max is the upper bound of High.
+// Calc may return higher values.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 0, Max: 100}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	if _, ok := a.table(p); !ok {
+		return goaqi.UNIT_UNSPECIFIED
+	}
+	return goaqi.UNIT_MIU_G_PER_M3
+}
+
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= 25 {
+		return LEVEL1
+	}
+	if aqi <= 50 {
+		return LEVEL2
+	}
+	if aqi <= 75 {
+		return LEVEL3
+	}
+	if aqi <= 100 {
+		return LEVEL4
+	}
+	return LEVEL5
+}
+
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
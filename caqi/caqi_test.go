@@ -0,0 +1,174 @@
+package caqi_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/caqi"
+)
+
+var (
+	_ goaqi.StandardWithColor  = &caqi.Algo{}
+	_ goaqi.StandardWithDetail = &caqi.Algo{}
+	_ goaqi.StandardWithScale  = &caqi.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.NO2_1H,
+			Value: 75,
+		},
+		{
+			P:     goaqi.O3_1H,
+			Value: 150,
+		},
+		{
+			P:     goaqi.PM10_1H,
+			Value: 40,
+		},
+	}
+	background, _, err := (&caqi.Algo{}).Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	roadside, _, err := (&caqi.Algo{Roadside: true}).Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("background=%v roadside=%v\n", background, roadside)
+	// Output: background=62 roadside=40
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type fields struct {
+		Roadside bool
+		Daily    bool
+	}
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr bool
+	}{
+		{
+			name:   "daily grid",
+			fields: fields{Daily: true},
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 40},
+					{P: goaqi.PM10_1H, Value: 200},
+				},
+			},
+			want:  62,
+			want1: []goaqi.Pollutant{goaqi.PM10_24H},
+		},
+		{
+			name: "hourly grid",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 40},
+					{P: goaqi.PM10_1H, Value: 40},
+				},
+			},
+			want:  40,
+			want1: []goaqi.Pollutant{goaqi.PM10_1H},
+		},
+		{
+			name: "extrapolated above 100",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_1H, Value: 600},
+				},
+			},
+			want:  125,
+			want1: []goaqi.Pollutant{goaqi.NO2_1H},
+		},
+		{
+			name:   "roadside ignores o3 and so2",
+			fields: fields{Roadside: true},
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_1H, Value: 200},
+					{P: goaqi.SO2_1H, Value: 400},
+					{P: goaqi.NO2_1H, Value: 10},
+				},
+			},
+			want: 5,
+		},
+		{
+			name: "zero",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_1H, Value: 0},
+				},
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &caqi.Algo{
+				Roadside: tt.fields.Roadside,
+				Daily:    tt.fields.Daily,
+			}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("caqi.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("caqi.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("caqi.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestAlgo_CalcWithDetail_roadside(t *testing.T) {
+	inputs := []*goaqi.Var{
+		{P: goaqi.O3_1H, Value: 200},
+		{P: goaqi.SO2_1H, Value: 400},
+		{P: goaqi.NO2_1H, Value: 10},
+	}
+	result, err := (&caqi.Algo{Roadside: true, Strict: true}).CalcWithDetail(inputs...)
+	if err != nil {
+		t.Fatalf("CalcWithDetail() error = %v", err)
+	}
+	if result.Warnings != nil {
+		t.Errorf("CalcWithDetail() Warnings = %v, want nil", result.Warnings)
+	}
+	if got := result.IAQIs[0].SkipReason; got != goaqi.SKIPREASON_NOT_APPLICABLE {
+		t.Errorf("CalcWithDetail() IAQIs[0].SkipReason = %v, want %v", got, goaqi.SKIPREASON_NOT_APPLICABLE)
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.NO2_1H, Value: 50},
+		unsupported,
+	}
+
+	if _, err := (&caqi.Algo{}).CalcWithDetail(inputs...); err != nil {
+		t.Fatalf("caqi.CalcWithDetail() error = %v", err)
+	}
+	_, err := (&caqi.Algo{Strict: true}).CalcWithDetail(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("caqi.CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
@@ -15,17 +15,163 @@ import (
 
 const Standard = goaqi.AQISTANDARD_US
 
-var tables = map[goaqi.Pollutant][]float64{
-	goaqi.AQI:       {0, 50, 100, 150, 200, 300, 400, 500},
-	goaqi.CO_8H:     {0, 4.4, 9.4, 12.4, 15.4, 30.4, 40.4, 50.4},      // ppm
-	goaqi.SO2_1H:    {0, 35, 75, 185, 304, 604, 804, 1004},            // ppb
-	goaqi.NO2_1H:    {0, 53, 100, 360, 649, 1249, 1649, 2049},         // ppb
-	goaqi.O3_8H:     {0, 0.054, 0.070, 0.085, 0.105, 0.2},             // ppm
-	goaqi.O3_1H:     {0, 0, 0.125, 0.164, 0.204, 0.404, 0.504, 0.604}, // ppm
-	goaqi.PM2_5_1H:  {0, 12, 35.4, 55.4, 150.4, 250.4, 350.4, 500.4},  // μg/m3
-	goaqi.PM2_5_24H: {0, 12, 35.4, 55.4, 150.4, 250.4, 350.4, 500.4},  // μg/m3
-	goaqi.PM10_1H:   {0, 54, 154, 254, 354, 424, 504, 604},            // μg/m3
-	goaqi.PM10_24H:  {0, 54, 154, 254, 354, 424, 504, 604},            // μg/m3
+// tables are breakpoints of Table 6 of the Technical Assistance Document, with
+// gaps between rows where truncated concentrations never fall.
+var tables = map[goaqi.Pollutant]goaqi.Table{
+	goaqi.CO_8H: { // ppm
+		{CLo: 0.0, CHi: 4.4, ILo: 0, IHi: 50},
+		{CLo: 4.5, CHi: 9.4, ILo: 51, IHi: 100},
+		{CLo: 9.5, CHi: 12.4, ILo: 101, IHi: 150},
+		{CLo: 12.5, CHi: 15.4, ILo: 151, IHi: 200},
+		{CLo: 15.5, CHi: 30.4, ILo: 201, IHi: 300},
+		{CLo: 30.5, CHi: 40.4, ILo: 301, IHi: 400},
+		{CLo: 40.5, CHi: 50.4, ILo: 401, IHi: 500},
+	},
+	goaqi.SO2_1H: { // ppb
+		{CLo: 0, CHi: 35, ILo: 0, IHi: 50},
+		{CLo: 36, CHi: 75, ILo: 51, IHi: 100},
+		{CLo: 76, CHi: 185, ILo: 101, IHi: 150},
+		{CLo: 186, CHi: 304, ILo: 151, IHi: 200},
+	},
+	goaqi.SO2_24H: { // ppb
+		{CLo: 305, CHi: 604, ILo: 201, IHi: 300},
+		{CLo: 605, CHi: 804, ILo: 301, IHi: 400},
+		{CLo: 805, CHi: 1004, ILo: 401, IHi: 500},
+	},
+	goaqi.NO2_1H: { // ppb
+		{CLo: 0, CHi: 53, ILo: 0, IHi: 50},
+		{CLo: 54, CHi: 100, ILo: 51, IHi: 100},
+		{CLo: 101, CHi: 360, ILo: 101, IHi: 150},
+		{CLo: 361, CHi: 649, ILo: 151, IHi: 200},
+		{CLo: 650, CHi: 1249, ILo: 201, IHi: 300},
+		{CLo: 1250, CHi: 1649, ILo: 301, IHi: 400},
+		{CLo: 1650, CHi: 2049, ILo: 401, IHi: 500},
+	},
+	goaqi.O3_8H: { // ppm
+		{CLo: 0.000, CHi: 0.054, ILo: 0, IHi: 50},
+		{CLo: 0.055, CHi: 0.070, ILo: 51, IHi: 100},
+		{CLo: 0.071, CHi: 0.085, ILo: 101, IHi: 150},
+		{CLo: 0.086, CHi: 0.105, ILo: 151, IHi: 200},
+		{CLo: 0.106, CHi: 0.200, ILo: 201, IHi: 300},
+	},
+	goaqi.O3_1H: { // ppm
+		{CLo: 0.125, CHi: 0.164, ILo: 101, IHi: 150},
+		{CLo: 0.165, CHi: 0.204, ILo: 151, IHi: 200},
+		{CLo: 0.205, CHi: 0.404, ILo: 201, IHi: 300},
+		{CLo: 0.405, CHi: 0.504, ILo: 301, IHi: 400},
+		{CLo: 0.505, CHi: 0.604, ILo: 401, IHi: 500},
+	},
+	goaqi.PM2_5_1H: { // μg/m3
+		{CLo: 0.0, CHi: 12.0, ILo: 0, IHi: 50},
+		{CLo: 12.1, CHi: 35.4, ILo: 51, IHi: 100},
+		{CLo: 35.5, CHi: 55.4, ILo: 101, IHi: 150},
+		{CLo: 55.5, CHi: 150.4, ILo: 151, IHi: 200},
+		{CLo: 150.5, CHi: 250.4, ILo: 201, IHi: 300},
+		{CLo: 250.5, CHi: 350.4, ILo: 301, IHi: 400},
+		{CLo: 350.5, CHi: 500.4, ILo: 401, IHi: 500},
+	},
+	goaqi.PM2_5_24H: { // μg/m3
+		{CLo: 0.0, CHi: 12.0, ILo: 0, IHi: 50},
+		{CLo: 12.1, CHi: 35.4, ILo: 51, IHi: 100},
+		{CLo: 35.5, CHi: 55.4, ILo: 101, IHi: 150},
+		{CLo: 55.5, CHi: 150.4, ILo: 151, IHi: 200},
+		{CLo: 150.5, CHi: 250.4, ILo: 201, IHi: 300},
+		{CLo: 250.5, CHi: 350.4, ILo: 301, IHi: 400},
+		{CLo: 350.5, CHi: 500.4, ILo: 401, IHi: 500},
+	},
+	goaqi.PM10_1H: { // μg/m3
+		{CLo: 0, CHi: 54, ILo: 0, IHi: 50},
+		{CLo: 55, CHi: 154, ILo: 51, IHi: 100},
+		{CLo: 155, CHi: 254, ILo: 101, IHi: 150},
+		{CLo: 255, CHi: 354, ILo: 151, IHi: 200},
+		{CLo: 355, CHi: 424, ILo: 201, IHi: 300},
+		{CLo: 425, CHi: 504, ILo: 301, IHi: 400},
+		{CLo: 505, CHi: 604, ILo: 401, IHi: 500},
+	},
+	goaqi.PM10_24H: { // μg/m3
+		{CLo: 0, CHi: 54, ILo: 0, IHi: 50},
+		{CLo: 55, CHi: 154, ILo: 51, IHi: 100},
+		{CLo: 155, CHi: 254, ILo: 101, IHi: 150},
+		{CLo: 255, CHi: 354, ILo: 151, IHi: 200},
+		{CLo: 355, CHi: 424, ILo: 201, IHi: 300},
+		{CLo: 425, CHi: 504, ILo: 301, IHi: 400},
+		{CLo: 505, CHi: 604, ILo: 401, IHi: 500},
+	},
+}
+
+// Version of the breakpoints.
+type Version int
+
+const (
+	VERSION_2018 Version = iota // EPA 454/B-18-007
+	VERSION_2024                // EPA-454/B-24-002, with revised PM2.5 breakpoints
+)
+
+// tables2024 overrides tables with the PM2.5 breakpoints revised in 2024, where
+// Good ends at 9.0 μg/m3 and Hazardous is a single 301-500 row.
+var tables2024 = map[goaqi.Pollutant]goaqi.Table{
+	goaqi.PM2_5_1H: { // μg/m3
+		{CLo: 0.0, CHi: 9.0, ILo: 0, IHi: 50},
+		{CLo: 9.1, CHi: 35.4, ILo: 51, IHi: 100},
+		{CLo: 35.5, CHi: 55.4, ILo: 101, IHi: 150},
+		{CLo: 55.5, CHi: 125.4, ILo: 151, IHi: 200},
+		{CLo: 125.5, CHi: 225.4, ILo: 201, IHi: 300},
+		{CLo: 225.5, CHi: 325.4, ILo: 301, IHi: 500},
+	},
+	goaqi.PM2_5_24H: { // μg/m3
+		{CLo: 0.0, CHi: 9.0, ILo: 0, IHi: 50},
+		{CLo: 9.1, CHi: 35.4, ILo: 51, IHi: 100},
+		{CLo: 35.5, CHi: 55.4, ILo: 101, IHi: 150},
+		{CLo: 55.5, CHi: 125.4, ILo: 151, IHi: 200},
+		{CLo: 125.5, CHi: 225.4, ILo: 201, IHi: 300},
+		{CLo: 225.5, CHi: 325.4, ILo: 301, IHi: 500},
+	},
+}
+
+// Windows are averages from hourly data. Data completeness of 40 CFR Part 50
+// asks for 6 of 8 hours for CO and O3 and 18 of 24 hours for PM.
+var Windows = []goaqi.Window{
+	{From: goaqi.CO_1H, To: goaqi.CO_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.O3_1H, To: goaqi.O3_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.PM10_1H, To: goaqi.PM10_24H, Hours: 24, MinValid: 18},
+	{From: goaqi.PM2_5_1H, To: goaqi.PM2_5_24H, Hours: 24, MinValid: 18},
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.CO_8H:     goaqi.UNIT_PPM,
+	goaqi.SO2_1H:    goaqi.UNIT_PPB,
+	goaqi.SO2_24H:   goaqi.UNIT_PPB,
+	goaqi.NO2_1H:    goaqi.UNIT_PPB,
+	goaqi.O3_8H:     goaqi.UNIT_PPM,
+	goaqi.O3_1H:     goaqi.UNIT_PPM,
+	goaqi.PM2_5_1H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM2_5_24H: goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM10_1H:   goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM10_24H:  goaqi.UNIT_MIU_G_PER_M3,
+}
+
+// truncations are decimal places concentrations are truncated to before
+// looking up breakpoints.
+var truncations = map[goaqi.Pollutant]int{
+	goaqi.CO_8H:     1,
+	goaqi.SO2_1H:    0,
+	goaqi.SO2_24H:   0,
+	goaqi.NO2_1H:    0,
+	goaqi.O3_8H:     3,
+	goaqi.O3_1H:     3,
+	goaqi.PM2_5_1H:  1,
+	goaqi.PM2_5_24H: 1,
+	goaqi.PM10_1H:   0,
+	goaqi.PM10_24H:  0,
+}
+
+// substitutes are pollutants used instead of those out of their domain or not
+// applicable.
+var substitutes = map[goaqi.Pollutant]goaqi.Pollutant{
+	goaqi.O3_8H:   goaqi.O3_1H,
+	goaqi.O3_1H:   goaqi.O3_8H,
+	goaqi.SO2_1H:  goaqi.SO2_24H,
+	goaqi.SO2_24H: goaqi.SO2_1H,
 }
 
 type AQILevel int
@@ -40,6 +186,15 @@ const (
 	LEVEL6
 )
 
+var levelToAQIRange = map[AQILevel][2]int{
+	LEVEL1: {0, 50},
+	LEVEL2: {50, 100},
+	LEVEL3: {100, 150},
+	LEVEL4: {150, 200},
+	LEVEL5: {200, 300},
+	LEVEL6: {300, 500},
+}
+
 var levelToColor = map[AQILevel]*color.RGBA{
 	LEVEL1: {R: 0, G: 228, B: 0},
 	LEVEL2: {R: 255, G: 255, B: 0},
@@ -58,66 +213,118 @@ var levelToDesc = map[AQILevel]string{
 	LEVEL6: "Hazardous",
 }
 
-type Algo struct{}
+type Algo struct {
+	// Version of breakpoints, VERSION_2018 by default. Use VERSION_2018 to
+	// recompute historical data reported before the 2024 revision.
+	Version Version
+
+	// Strict returns *goaqi.SkippedError for unsupported pollutants and values
+	// out of their domain, like 8-hour O3 above 0.200 ppm, instead of skipping
+	// them silently.
+	Strict bool
+}
 
 func (a *Algo) Name() string {
 	return "epa"
 }
 
 // Calc is func for realtime AQI report computing.
+//
+// Concentrations are truncated before looking up breakpoints and AQI is rounded
+// to the nearest integer, as required by the Technical Assistance Document.
+//
+// O3 sub-indices are computed from both 8-hour and 1-hour concentrations if
+// given, and the higher one is reported. 8-hour O3 defines AQI up to 0.200 ppm
+// and 1-hour O3 from 0.125 ppm, beyond which each is skipped for the other, see
+// goaqi.Result.Warnings.
 func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
-	var (
-		results = make(map[goaqi.Pollutant]int)
-		maxAQI  int
-	)
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns sub-index of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
 
 	for _, pollutantVar := range pollutantVars {
-		pollutantIndexRange, ok := tables[pollutantVar.P]
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		table, ok := a.table(pollutantVar.P)
 		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
 			continue
 		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+		value = goaqi.Truncate(value, truncations[pollutantVar.P])
 
 		// 8-hour O 3 values do not define higher AQI values (≥ 301).
 		// AQI values of 301 or higher are calculated with 1-hour O 3 concentrations.
-		if pollutantVar.P == goaqi.O3_8H && pollutantVar.Value > 0.2 {
+		if pollutantVar.P == goaqi.O3_8H && value > 0.2 {
+			iaqi.SkipReason = goaqi.SKIPREASON_OUT_OF_DOMAIN
+			continue
+		}
+
+		// 1-hour O 3 values do not define lower AQI values (≤ 100).
+		// AQI values of 100 or lower are calculated with 8-hour O 3 concentrations.
+		if pollutantVar.P == goaqi.O3_1H && value < 0.125 {
+			iaqi.SkipReason = goaqi.SKIPREASON_NOT_APPLICABLE
 			continue
 		}
 
 		// 1-hour SO 2 values do not define higher AQI values (≥ 200).
 		// AQI values of 200 or greater are calculated with 24-hour SO 2 concentrations.
-		if pollutantVar.P == goaqi.SO2_1H && pollutantVar.Value > 304 {
+		if pollutantVar.P == goaqi.SO2_1H && value > 304 {
+			iaqi.SkipReason = goaqi.SKIPREASON_OUT_OF_DOMAIN
 			continue
 		}
 
-		aqi, err := func() (int, error) {
-			if pollutantVar.Value > pollutantIndexRange[len(pollutantIndexRange)-1] {
-				return 500, nil
-			}
-			iaqiLo, iaqiHi, pLo, pHi, err := goaqi.GetRanges(pollutantVar.Value, pollutantIndexRange, tables[goaqi.AQI])
-			if err != nil {
-				return 0, err
-			}
-			return goaqi.CalcViaHiLo(pollutantVar.Value, iaqiLo, iaqiHi, pLo, pHi)
-		}()
-		if err != nil {
-			return 0, nil, err
+		// 24-hour SO 2 values do not define lower AQI values (≤ 200).
+		// AQI values below 201 are calculated with 1-hour SO 2 concentrations.
+		if pollutantVar.P == goaqi.SO2_24H && value < 305 {
+			iaqi.SkipReason = goaqi.SKIPREASON_NOT_APPLICABLE
+			continue
 		}
 
-		if aqi > maxAQI {
-			maxAQI = aqi
+		rawAQI, breakpoint, err := table.Calc(value)
+		if err != nil {
+			return nil, err
 		}
-		results[pollutantVar.P] = aqi
+		iaqi.Value, iaqi.RawValue, iaqi.Breakpoint, iaqi.Skipped = goaqi.RoundHalfUp(rawAQI), rawAQI, breakpoint, false
 	}
-	if maxAQI <= 50 {
-		return maxAQI, nil, nil
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
 	}
-	primaryPollutants := make([]goaqi.Pollutant, 0)
-	for pollutant, value := range results {
-		if value == maxAQI {
-			primaryPollutants = append(primaryPollutants, pollutant)
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundHalfUp, 50, substitutes), nil
+}
+
+// table returns breakpoints of p for a.Version.
+func (a *Algo) table(p goaqi.Pollutant) (goaqi.Table, bool) {
+	if a.Version == VERSION_2024 {
+		if table, ok := tables2024[p]; ok {
+			return table, true
 		}
 	}
-	return maxAQI, primaryPollutants, nil
+	table, ok := tables[p]
+	return table, ok
+}
+
+// Scale returns the range of AQI.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 0, Max: 500}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
 }
 
 func (a *Algo) AQIToLevel(aqi int) AQILevel {
@@ -154,3 +361,38 @@ func (a *Algo) AQIToDesc(aqi int) (string, error) {
 	}
 	return desc, nil
 }
+
+// AQIToConcentration returns the lowest concentration of p, in the unit from
+// ExpectedUnit, whose sub-index is aqi.
+func (a *Algo) AQIToConcentration(p goaqi.Pollutant, aqi int) (float64, error) {
+	table, ok := a.table(p)
+	if !ok {
+		return 0, fmt.Errorf("go-aqi: unsupported pollutant %v", p)
+	}
+	return table.Inverse(float64(aqi))
+}
+
+// LevelToConcentration returns the concentration range (lo, hi] of p, in the
+// unit from ExpectedUnit, whose sub-index falls in level. Like how much PM2.5
+// must drop to reach LEVEL2, or legends in native units.
+func (a *Algo) LevelToConcentration(p goaqi.Pollutant, level AQILevel) (lo float64, hi float64, err error) {
+	aqiRange, ok := levelToAQIRange[level]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown aqi level for concentration")
+	}
+	table, ok := a.table(p)
+	if !ok {
+		return 0, 0, fmt.Errorf("go-aqi: unsupported pollutant %v", p)
+	}
+	// Tables starting above the level, like 1-hour O3 from AQI 101, start it at
+	// their lowest concentration.
+	lo, err = table.Inverse(max(float64(aqiRange[0]), table[0].ILo))
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err = a.AQIToConcentration(p, aqiRange[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return lo, hi, nil
+}
@@ -1,14 +1,21 @@
 package epa
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 
 	goaqi "github.com/ringsaturn/go-aqi"
 )
 
-var _ goaqi.StandardWithColor = &Algo{}
+var (
+	_ goaqi.StandardWithColor   = &Algo{}
+	_ goaqi.StandardWithDetail  = &Algo{}
+	_ goaqi.StandardWithInverse = &Algo{}
+	_ goaqi.StandardWithScale   = &Algo{}
+)
 
 func ExampleAlgo_Calc() {
 	algo := &Algo{}
@@ -32,7 +39,8 @@ func ExampleAlgo_Calc() {
 
 func TestAlgo_Calc(t *testing.T) {
 	type fields struct {
-		FailedWhenNotSupported bool
+		Strict  bool
+		Version Version
 	}
 	type args struct {
 		pollutantVars []*goaqi.Var
@@ -48,7 +56,7 @@ func TestAlgo_Calc(t *testing.T) {
 		{
 			name: "example",
 			fields: fields{
-				FailedWhenNotSupported: false,
+				Strict: false,
 			},
 			args: args{
 				pollutantVars: func() []*goaqi.Var {
@@ -73,10 +81,236 @@ func TestAlgo_Calc(t *testing.T) {
 			want1:   []goaqi.Pollutant{goaqi.PM10_1H},
 			wantErr: false,
 		},
+		{
+			name: "convert units",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_1H, Value: 0.016, Unit: goaqi.UNIT_MG_PER_M3},
+					{P: goaqi.CO_8H, Value: 10.3, Unit: goaqi.UNIT_MG_PER_M3},
+					{P: goaqi.NO2_1H, Value: 200, Unit: goaqi.UNIT_MIU_G_PER_M3},
+				},
+			},
+			want:    102,
+			want1:   []goaqi.Pollutant{goaqi.NO2_1H},
+			wantErr: false,
+		},
+		{
+			name: "pm2.5 of version 2018",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.PM2_5_24H, Value: 12}},
+			},
+			want:    50,
+			want1:   nil,
+			wantErr: false,
+		},
+		{
+			name: "pm2.5 of version 2024",
+			fields: fields{
+				Version: VERSION_2024,
+			},
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.PM2_5_24H, Value: 12}},
+			},
+			want:    56,
+			want1:   []goaqi.Pollutant{goaqi.PM2_5_24H},
+			wantErr: false,
+		},
+		{
+			name: "hazardous pm2.5 of version 2024",
+			fields: fields{
+				Version: VERSION_2024,
+			},
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.PM2_5_24H, Value: 300}},
+			},
+			want:    449,
+			want1:   []goaqi.Pollutant{goaqi.PM2_5_24H},
+			wantErr: false,
+		},
+		{
+			name: "truncate o3 to 3 decimals",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.O3_8H, Value: 0.0549}},
+			},
+			want:    50,
+			want1:   nil,
+			wantErr: false,
+		},
+		{
+			name: "round to nearest",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.PM2_5_24H, Value: 40}},
+			},
+			want:    112,
+			want1:   []goaqi.Pollutant{goaqi.PM2_5_24H},
+			wantErr: false,
+		},
+		{
+			name: "zero",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.PM10_24H, Value: 0}},
+			},
+			want:    0,
+			want1:   nil,
+			wantErr: false,
+		},
+		{
+			name: "lower bound of segment",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.PM10_24H, Value: 55}},
+			},
+			want:    51,
+			want1:   []goaqi.Pollutant{goaqi.PM10_24H},
+			wantErr: false,
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.PM10_24H, Value: -1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NaN value",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.PM10_24H, Value: math.NaN()}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "8-hour O3 with 1-hour O3 not applicable",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 0.075},
+					{P: goaqi.O3_1H, Value: 0.1},
+				},
+			},
+			want:    115,
+			want1:   []goaqi.Pollutant{goaqi.O3_8H},
+			wantErr: false,
+		},
+		{
+			name: "higher 1-hour O3",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 0.075},
+					{P: goaqi.O3_1H, Value: 0.15},
+				},
+			},
+			want:    132,
+			want1:   []goaqi.Pollutant{goaqi.O3_1H},
+			wantErr: false,
+		},
+		{
+			name: "lowest 1-hour O3",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.O3_1H, Value: 0.125}},
+			},
+			want:    101,
+			want1:   []goaqi.Pollutant{goaqi.O3_1H},
+			wantErr: false,
+		},
+		{
+			name: "1-hour O3 above 8-hour O3 domain",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 0.25},
+					{P: goaqi.O3_1H, Value: 0.3},
+				},
+			},
+			want:    248,
+			want1:   []goaqi.Pollutant{goaqi.O3_1H},
+			wantErr: false,
+		},
+		{
+			name: "strict on 1-hour O3 not applicable",
+			fields: fields{
+				Strict: true,
+			},
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 0.075},
+					{P: goaqi.O3_1H, Value: 0.1},
+				},
+			},
+			want:    115,
+			want1:   []goaqi.Pollutant{goaqi.O3_8H},
+			wantErr: false,
+		},
+		{
+			name: "lowest 24-hour SO2",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.SO2_24H, Value: 305}},
+			},
+			want:    201,
+			want1:   []goaqi.Pollutant{goaqi.SO2_24H},
+			wantErr: false,
+		},
+		{
+			name: "24-hour SO2",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.SO2_24H, Value: 454}},
+			},
+			want:    250,
+			want1:   []goaqi.Pollutant{goaqi.SO2_24H},
+			wantErr: false,
+		},
+		{
+			name: "highest 24-hour SO2",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.SO2_24H, Value: 1004}},
+			},
+			want:    500,
+			want1:   []goaqi.Pollutant{goaqi.SO2_24H},
+			wantErr: false,
+		},
+		{
+			name: "24-hour SO2 below 305 is not applicable",
+			args: args{
+				pollutantVars: []*goaqi.Var{{P: goaqi.SO2_24H, Value: 100}},
+			},
+			want:    0,
+			want1:   nil,
+			wantErr: false,
+		},
+		{
+			name: "skip unsupported pollutant",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 88},
+					{P: goaqi.CO_1H, Value: 0.2},
+				},
+			},
+			want:    67,
+			want1:   []goaqi.Pollutant{goaqi.PM10_24H},
+			wantErr: false,
+		},
+		{
+			name: "strict on unsupported pollutant",
+			fields: fields{
+				Strict: true,
+			},
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 88},
+					{P: goaqi.CO_1H, Value: 0.2},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unconvertible unit",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_1H, Value: 3, Unit: goaqi.UNIT_PPM},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			a := &Algo{}
+			a := &Algo{Version: tt.fields.Version, Strict: tt.fields.Strict}
 			got, got1, err := a.Calc(tt.args.pollutantVars...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("goaqi.Calc() error = %v, wantErr %v", err, tt.wantErr)
@@ -92,6 +326,101 @@ func TestAlgo_Calc(t *testing.T) {
 	}
 }
 
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	co := &goaqi.Var{P: goaqi.CO_1H, Value: 0.2}
+	o3 := &goaqi.Var{P: goaqi.O3_8H, Value: 0.3}
+	pm10 := &goaqi.Var{P: goaqi.PM10_24H, Value: 88}
+
+	result, err := (&Algo{}).CalcWithDetail(co, o3, pm10)
+	if err != nil {
+		t.Fatalf("CalcWithDetail() error = %v", err)
+	}
+	reasons := []goaqi.SkipReason{goaqi.SKIPREASON_UNSUPPORTED, goaqi.SKIPREASON_OUT_OF_DOMAIN, goaqi.SKIPREASON_NONE}
+	for i, iaqi := range result.IAQIs {
+		if iaqi.SkipReason != reasons[i] {
+			t.Errorf("CalcWithDetail() IAQIs[%d].SkipReason = %v, want %v", i, iaqi.SkipReason, reasons[i])
+		}
+	}
+
+	_, err = (&Algo{Strict: true}).CalcWithDetail(co, o3, pm10)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{co, o3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
+
+func TestAlgo_CalcWithDetail_warnings(t *testing.T) {
+	o3_8h := &goaqi.Var{P: goaqi.O3_8H, Value: 0.3}
+	o3_1h := &goaqi.Var{P: goaqi.O3_1H, Value: 0.3}
+	so2_1h := &goaqi.Var{P: goaqi.SO2_1H, Value: 400}
+	so2_24h := &goaqi.Var{P: goaqi.SO2_24H, Value: 400}
+	pm25 := &goaqi.Var{P: goaqi.PM2_5_24H, Value: 600}
+	co := &goaqi.Var{P: goaqi.CO_1H, Value: 0.2}
+
+	tests := []struct {
+		name          string
+		pollutantVars []*goaqi.Var
+		want          int
+		wantWarnings  []*goaqi.Warning
+	}{
+		{
+			name:          "substituted",
+			pollutantVars: []*goaqi.Var{o3_8h, o3_1h},
+			want:          248,
+			wantWarnings:  []*goaqi.Warning{{Kind: goaqi.WARNINGKIND_SUBSTITUTED, Var: o3_8h, Substitute: goaqi.O3_1H}},
+		},
+		{
+			name:          "missing substitute",
+			pollutantVars: []*goaqi.Var{so2_1h},
+			want:          0,
+			wantWarnings:  []*goaqi.Warning{{Kind: goaqi.WARNINGKIND_MISSING_SUBSTITUTE, Var: so2_1h, Substitute: goaqi.SO2_24H}},
+		},
+		{
+			name:          "24-hour SO2",
+			pollutantVars: []*goaqi.Var{so2_1h, so2_24h},
+			want:          232,
+			wantWarnings:  []*goaqi.Warning{{Kind: goaqi.WARNINGKIND_SUBSTITUTED, Var: so2_1h, Substitute: goaqi.SO2_24H}},
+		},
+		{
+			name:          "no warnings for 24-hour SO2 not applicable",
+			pollutantVars: []*goaqi.Var{{P: goaqi.SO2_1H, Value: 20}, {P: goaqi.SO2_24H, Value: 100}},
+			want:          29,
+		},
+		{
+			name:          "1-hour O3 not applicable without 8-hour O3",
+			pollutantVars: []*goaqi.Var{{P: goaqi.O3_1H, Value: 0.1}},
+			want:          0,
+			wantWarnings:  []*goaqi.Warning{{Kind: goaqi.WARNINGKIND_MISSING_SUBSTITUTE, Var: &goaqi.Var{P: goaqi.O3_1H, Value: 0.1}, Substitute: goaqi.O3_8H}},
+		},
+		{
+			name:          "capped and skipped",
+			pollutantVars: []*goaqi.Var{pm25, co},
+			want:          500,
+			wantWarnings: []*goaqi.Warning{
+				{Kind: goaqi.WARNINGKIND_CAPPED, Var: pm25},
+				{Kind: goaqi.WARNINGKIND_SKIPPED, Var: co},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := (&Algo{}).CalcWithDetail(tt.pollutantVars...)
+			if err != nil {
+				t.Fatalf("CalcWithDetail() error = %v", err)
+			}
+			if result.AQI != tt.want {
+				t.Errorf("CalcWithDetail() AQI = %v, want %v", result.AQI, tt.want)
+			}
+			if !reflect.DeepEqual(result.Warnings, tt.wantWarnings) {
+				t.Errorf("CalcWithDetail() Warnings = %v, want %v", result.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
 func ExampleAlgo_AQIToColor() {
 	algo := &Algo{}
 	rgba, err := algo.AQIToColor(33)
@@ -111,3 +440,75 @@ func ExampleAlgo_AQIToDesc() {
 	fmt.Printf("%v", desc)
 	// Output: Good
 }
+
+func ExampleAlgo_LevelToConcentration() {
+	algo := &Algo{Version: VERSION_2024}
+	lo, hi, err := algo.LevelToConcentration(goaqi.PM2_5_24H, LEVEL2)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("(%v, %v]", lo, hi)
+	// Output: (9, 35.4]
+}
+
+func TestAlgo_AQIToConcentration(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       goaqi.Pollutant
+		aqi     int
+		want    float64
+		wantErr bool
+	}{
+		{name: "lowest", p: goaqi.PM10_24H, aqi: 0, want: 0},
+		{name: "boundary", p: goaqi.PM10_24H, aqi: 100, want: 154},
+		{name: "inside segment", p: goaqi.CO_8H, aqi: 75, want: 6.9},
+		{name: "lower bound of segment", p: goaqi.PM2_5_24H, aqi: 51, want: 12.1},
+		{name: "highest", p: goaqi.CO_8H, aqi: 500, want: 50.4},
+		{name: "lowest of 1-hour O3", p: goaqi.O3_1H, aqi: 101, want: 0.125},
+		{name: "undefined for 1-hour O3", p: goaqi.O3_1H, aqi: 100, wantErr: true},
+		{name: "undefined for 8-hour O3", p: goaqi.O3_8H, aqi: 350, wantErr: true},
+		{name: "unsupported pollutant", p: goaqi.CO_1H, aqi: 50, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Algo{}
+			got, err := a.AQIToConcentration(tt.p, tt.aqi)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AQIToConcentration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("AQIToConcentration() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgo_LevelToConcentration(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       goaqi.Pollutant
+		level   AQILevel
+		wantLo  float64
+		wantHi  float64
+		wantErr bool
+	}{
+		{name: "inside table", p: goaqi.PM10_24H, level: LEVEL2, wantLo: 54, wantHi: 154},
+		{name: "level starting 1-hour O3", p: goaqi.O3_1H, level: LEVEL3, wantLo: 0.125, wantHi: 0.164},
+		{name: "below 1-hour O3", p: goaqi.O3_1H, level: LEVEL2, wantErr: true},
+		{name: "level starting 24-hour SO2", p: goaqi.SO2_24H, level: LEVEL5, wantLo: 305, wantHi: 604},
+		{name: "below 24-hour SO2", p: goaqi.SO2_24H, level: LEVEL4, wantErr: true},
+		{name: "unknown level", p: goaqi.PM10_24H, level: LEVEL_UNDEFINE, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Algo{}
+			lo, hi, err := a.LevelToConcentration(tt.p, tt.level)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LevelToConcentration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if lo != tt.wantLo || hi != tt.wantHi {
+				t.Errorf("LevelToConcentration() got = (%v, %v], want (%v, %v]", lo, hi, tt.wantLo, tt.wantHi)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package epa
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+type nowCastRule struct {
+	P         goaqi.Pollutant // pollutant reported to Calc
+	Hours     int
+	MinWeight float64
+}
+
+// AirNow computes NowCast of PM over 12 hours with a minimum weight factor of
+// 0.5 and of O3 over 8 hours without a minimum weight factor.
+var nowCastRules = map[goaqi.Pollutant]nowCastRule{
+	goaqi.PM2_5_1H: {P: goaqi.PM2_5_24H, Hours: 12, MinWeight: 0.5},
+	goaqi.PM10_1H:  {P: goaqi.PM10_24H, Hours: 12, MinWeight: 0.5},
+	goaqi.O3_1H:    {P: goaqi.O3_8H, Hours: 8, MinWeight: 0},
+}
+
+// NowCast returns the NowCast concentration at the hour of end from hourly
+// observations of p, which should be one of PM2_5_1H, PM10_1H or O3_1H.
+//
+// The returned Var is PM2_5_24H, PM10_24H or O3_8H in the expected unit, ready
+// to pass to Calc.
+func NowCast(p goaqi.Pollutant, end time.Time, observations ...*goaqi.Observation) (*goaqi.Var, error) {
+	rule, ok := nowCastRules[p]
+	if !ok {
+		return nil, fmt.Errorf("go-aqi: NowCast not supported for %v", p)
+	}
+	unit := units[rule.P]
+	values, err := goaqi.HourlyValues(observations, p, unit, end, rule.Hours)
+	if err != nil {
+		return nil, err
+	}
+	value, err := goaqi.NowCast(values, rule.MinWeight)
+	if err != nil {
+		return nil, fmt.Errorf("go-aqi: NowCast of %v at %v: %w", p, end, err)
+	}
+	return &goaqi.Var{P: rule.P, Value: value, Unit: unit}, nil
+}
+
+// CalcNowCast computes AQI at the hour of end like AirNow does, using NowCast
+// concentrations of PM2.5, PM10 and O3. Other pollutants use the observation
+// of that hour as is.
+//
+// Pollutants without enough hours for NowCast are ignored.
+func (a *Algo) CalcNowCast(end time.Time, observations ...*goaqi.Observation) (int, []goaqi.Pollutant, error) {
+	var (
+		pollutantVars = make([]*goaqi.Var, 0)
+		seen          = make(map[goaqi.Pollutant]bool)
+		hour          = end.Truncate(time.Hour)
+	)
+	for _, observation := range observations {
+		if _, ok := nowCastRules[observation.P]; !ok {
+			if observation.Time.Truncate(time.Hour).Equal(hour) {
+				pollutantVars = append(pollutantVars, &observation.Var)
+			}
+			continue
+		}
+		if seen[observation.P] {
+			continue
+		}
+		seen[observation.P] = true
+
+		pollutantVar, err := NowCast(observation.P, end, observations...)
+		if errors.Is(err, goaqi.ErrInsufficientData) {
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		pollutantVars = append(pollutantVars, pollutantVar)
+	}
+	return a.Calc(pollutantVars...)
+}
@@ -0,0 +1,82 @@
+package epa
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+func ExampleAlgo_CalcNowCast() {
+	algo := &Algo{}
+	end := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	observations := goaqi.HourlyObservations(goaqi.PM2_5_1H, end, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40)
+	observations = append(observations, goaqi.HourlyObservations(goaqi.NO2_1H, end, 50)...)
+
+	aqi, primaryPollutant, err := algo.CalcNowCast(end, observations...)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("aqi=%v with primary pollutant as %v\n", aqi, primaryPollutant)
+	// Output: aqi=112 with primary pollutant as [PM2_5_24H]
+}
+
+func TestNowCast(t *testing.T) {
+	end := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	nan := math.NaN()
+	tests := []struct {
+		name         string
+		p            goaqi.Pollutant
+		observations []*goaqi.Observation
+		want         *goaqi.Var
+		wantErr      error
+	}{
+		{
+			name:         "stable",
+			p:            goaqi.PM2_5_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM2_5_1H, end, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20),
+			want:         &goaqi.Var{P: goaqi.PM2_5_24H, Value: 20, Unit: goaqi.UNIT_MIU_G_PER_M3},
+		},
+		{
+			name:         "pm minimum weight factor",
+			p:            goaqi.PM10_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM10_1H, end, 10, 40),
+			want:         &goaqi.Var{P: goaqi.PM10_24H, Value: 20, Unit: goaqi.UNIT_MIU_G_PER_M3},
+		},
+		{
+			name:         "o3 without minimum weight factor",
+			p:            goaqi.O3_1H,
+			observations: goaqi.HourlyObservations(goaqi.O3_1H, end, 0.01, nan, 0.04),
+			want:         &goaqi.Var{P: goaqi.O3_8H, Value: 0.0125 / 1.0625, Unit: goaqi.UNIT_PPM},
+		},
+		{
+			name:         "ignore hours out of window",
+			p:            goaqi.O3_1H,
+			observations: goaqi.HourlyObservations(goaqi.O3_1H, end, 0.03, 0.03, nan, nan, nan, nan, nan, nan, 1),
+			want:         &goaqi.Var{P: goaqi.O3_8H, Value: 0.03, Unit: goaqi.UNIT_PPM},
+		},
+		{
+			name:         "insufficient recent hours",
+			p:            goaqi.PM2_5_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM2_5_1H, end, 10, nan, nan, 20, 20, 20),
+			wantErr:      goaqi.ErrInsufficientData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NowCast(tt.p, end, tt.observations...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NowCast() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				return
+			}
+			if got.P != tt.want.P || got.Unit != tt.want.Unit || math.Abs(got.Value-tt.want.Value) > 1e-9 {
+				t.Errorf("NowCast() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
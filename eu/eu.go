@@ -0,0 +1,191 @@
+// Package eu is for European Air Quality Index
+//
+// The index is published by European Environment Agency (EEA), which reports
+// one of six bands from Good to Extremely poor rather than a number.
+//
+// Offical Doc
+// https://airindex.eea.europa.eu/AQI/index.html
+package eu
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_EU
+
+// tables are upper bounds of band 1 to 5, values above the last one are band 6
+// as Extremely poor.
+var tables = map[goaqi.Pollutant][]float64{
+	goaqi.PM2_5_1H: {0, 10, 20, 25, 50, 75},      // μg/m3
+	goaqi.PM10_1H:  {0, 20, 40, 50, 100, 150},    // μg/m3
+	goaqi.NO2_1H:   {0, 40, 90, 120, 230, 340},   // μg/m3
+	goaqi.O3_1H:    {0, 50, 100, 130, 240, 380},  // μg/m3
+	goaqi.SO2_1H:   {0, 100, 200, 350, 500, 750}, // μg/m3
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+	LEVEL5
+	LEVEL6
+)
+
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 80, G: 240, B: 230},
+	LEVEL2: {R: 80, G: 204, B: 170},
+	LEVEL3: {R: 240, G: 230, B: 65},
+	LEVEL4: {R: 255, G: 80, B: 80},
+	LEVEL5: {R: 150, G: 0, B: 50},
+	LEVEL6: {R: 125, G: 33, B: 129},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Good",
+	LEVEL2: "Fair",
+	LEVEL3: "Moderate",
+	LEVEL4: "Poor",
+	LEVEL5: "Very poor",
+	LEVEL6: "Extremely poor",
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "eu"
+}
+
+// Calc is func for hourly index computing, which returns the band from 1 as
+// Good to 6 as Extremely poor, the worst band of all pollutants.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns band of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		pollutantIndexRange, ok := tables[pollutantVar.P]
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+		if err := goaqi.ValidateValue(value); err != nil {
+			return nil, err
+		}
+
+		band := int(LEVEL6)
+		for i := 1; i < len(pollutantIndexRange); i++ {
+			if value <= pollutantIndexRange[i] {
+				band = i
+				break
+			}
+		}
+		iaqi.Value, iaqi.RawValue, iaqi.Skipped = band, float64(band), false
+		iaqi.Breakpoint = &goaqi.Breakpoint{
+			CLo: pollutantIndexRange[band-1],
+			CHi: math.Inf(1),
+			ILo: float64(band),
+			IHi: float64(band),
+		}
+		if band < int(LEVEL6) {
+			iaqi.Breakpoint.CHi = pollutantIndexRange[band]
+		}
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundDown, int(LEVEL1), nil), nil
+}
+
+// Scale returns the range of bands.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: int(LEVEL1), Max: int(LEVEL6), Categorical: true}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	if _, ok := tables[p]; !ok {
+		return goaqi.UNIT_UNSPECIFIED
+	}
+	return goaqi.UNIT_MIU_G_PER_M3
+}
+
+// AQIToLevel returns the level of band aqi, which is the band itself.
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= int(LEVEL1) {
+		return LEVEL1
+	}
+	if aqi >= int(LEVEL6) {
+		return LEVEL6
+	}
+	return AQILevel(aqi)
+}
+
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
+
+// AQIToConcentration returns the lowest concentration of p in μg/m3 whose
+// band is aqi. The lower bound is exclusive except for the first band.
+func (a *Algo) AQIToConcentration(p goaqi.Pollutant, aqi int) (float64, error) {
+	pollutantIndexRange, ok := tables[p]
+	if !ok {
+		return 0, fmt.Errorf("go-aqi: unsupported pollutant %v", p)
+	}
+	if aqi < int(LEVEL1) || aqi > int(LEVEL6) {
+		return 0, fmt.Errorf("go-aqi: bad band %v", aqi)
+	}
+	return pollutantIndexRange[aqi-1], nil
+}
+
+// LevelToConcentration returns the concentration range (lo, hi] of p in μg/m3
+// of level, hi is +Inf for LEVEL6.
+func (a *Algo) LevelToConcentration(p goaqi.Pollutant, level AQILevel) (lo float64, hi float64, err error) {
+	lo, err = a.AQIToConcentration(p, int(level))
+	if err != nil {
+		return 0, 0, err
+	}
+	if level == LEVEL6 {
+		return lo, math.Inf(1), nil
+	}
+	return lo, tables[p][level], nil
+}
@@ -0,0 +1,191 @@
+package eu_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/eu"
+)
+
+var (
+	_ goaqi.StandardWithColor   = &eu.Algo{}
+	_ goaqi.StandardWithDetail  = &eu.Algo{}
+	_ goaqi.StandardWithInverse = &eu.Algo{}
+	_ goaqi.StandardWithScale   = &eu.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	algo := &eu.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.PM2_5_1H,
+			Value: 16,
+		},
+		{
+			P:     goaqi.PM10_1H,
+			Value: 88,
+		},
+		{
+			P:     goaqi.NO2_1H,
+			Value: 11,
+		},
+		{
+			P:     goaqi.O3_1H,
+			Value: 75,
+		},
+	}
+	band, primaryPollutant, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(band)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("band=%v as level=%v with primary pollutant as %v\n", band, desc, primaryPollutant)
+	// Output: band=4 as level=Poor with primary pollutant as [PM10_1H]
+}
+
+func ExampleAlgo_AQIToColor() {
+	algo := &eu.Algo{}
+	rgba, err := algo.AQIToColor(1)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%v", rgba)
+	// Output: &{80 240 230 0}
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr bool
+	}{
+		{
+			name: "good",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_1H, Value: 0},
+					{P: goaqi.NO2_1H, Value: 40},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "band upper bound is inclusive",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_1H, Value: 100},
+					{P: goaqi.SO2_1H, Value: 150},
+				},
+			},
+			want:  2,
+			want1: []goaqi.Pollutant{goaqi.O3_1H, goaqi.SO2_1H},
+		},
+		{
+			name: "above highest breakpoint",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_1H, Value: 2000},
+					{P: goaqi.CO_1H, Value: 2000},
+				},
+			},
+			want:  6,
+			want1: []goaqi.Pollutant{goaqi.PM10_1H},
+		},
+		{
+			name: "convert units",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_1H, Value: 0.1, Unit: goaqi.UNIT_PPM},
+				},
+			},
+			want:  4,
+			want1: []goaqi.Pollutant{goaqi.NO2_1H},
+		},
+		{
+			name: "NaN value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_1H, Value: math.NaN()},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_1H, Value: -1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &eu.Algo{}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("eu.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("eu.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("eu.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestAlgo_CalcWithDetail_openBand(t *testing.T) {
+	a := &eu.Algo{}
+	result, err := a.CalcWithDetail(&goaqi.Var{P: goaqi.PM2_5_1H, Value: 900})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &goaqi.Breakpoint{CLo: 75, CHi: math.Inf(1), ILo: 6, IHi: 6}
+	if got := result.IAQIs[0].Breakpoint; !reflect.DeepEqual(got, want) {
+		t.Errorf("eu.CalcWithDetail() breakpoint = %+v, want %+v", got, want)
+	}
+
+	lo, hi, err := a.LevelToConcentration(goaqi.PM2_5_1H, eu.LEVEL6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lo != 75 || !math.IsInf(hi, 1) {
+		t.Errorf("eu.LevelToConcentration() got = (%v, %v], want (75, +Inf]", lo, hi)
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.PM2_5_1H, Value: 5},
+		unsupported,
+	}
+
+	if _, err := (&eu.Algo{}).CalcWithDetail(inputs...); err != nil {
+		t.Fatalf("eu.CalcWithDetail() error = %v", err)
+	}
+	_, err := (&eu.Algo{Strict: true}).CalcWithDetail(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("eu.CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
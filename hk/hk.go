@@ -0,0 +1,187 @@
+// Package hk is for Air Quality Health Index of Hong Kong
+//
+// AQHI is based on the percentage added health risk (%AR) of 3-hour moving
+// average NO2, SO2, O3 and PM, where PM is the higher one of PM10 and PM2.5,
+// reported from 1 to 10 and 10+ above 10.
+//
+// Offical Doc
+// https://www.aqhi.gov.hk/en/what-is-aqhi/about-aqhi.html
+package hk
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_HK
+
+// AQHIPlus is the AQHI reported as 10+.
+const AQHIPlus = 11
+
+// ErrMissingPollutant is returned if any of NO2, SO2, O3 is missing, or both
+// PM10 and PM2.5 are missing.
+var ErrMissingPollutant = errors.New("go-aqi: hk requires NO2, SO2, O3 and PM10 or PM2.5")
+
+// coefficients of added risk per μg/m3.
+var coefficients = map[goaqi.Pollutant]float64{
+	goaqi.NO2_3H:   0.0004462559,
+	goaqi.SO2_3H:   0.0001393235,
+	goaqi.O3_3H:    0.0005116328,
+	goaqi.PM10_3H:  0.0002821751,
+	goaqi.PM2_5_3H: 0.0002180567,
+}
+
+// bands are upper bounds of %AR of AQHI 1 to 10, values above the last one are
+// AQHIPlus.
+var bands = []float64{1.88, 3.76, 5.64, 7.52, 9.41, 11.29, 12.91, 15.07, 17.22, 19.37}
+
+// Windows are 3-hour moving averages from hourly data.
+var Windows = []goaqi.Window{
+	{From: goaqi.NO2_1H, To: goaqi.NO2_3H, Hours: 3, MinValid: 2},
+	{From: goaqi.SO2_1H, To: goaqi.SO2_3H, Hours: 3, MinValid: 2},
+	{From: goaqi.O3_1H, To: goaqi.O3_3H, Hours: 3, MinValid: 2},
+	{From: goaqi.PM10_1H, To: goaqi.PM10_3H, Hours: 3, MinValid: 2},
+	{From: goaqi.PM2_5_1H, To: goaqi.PM2_5_3H, Hours: 3, MinValid: 2},
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+	LEVEL5
+)
+
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 0, G: 176, B: 80},
+	LEVEL2: {R: 255, G: 165, B: 0},
+	LEVEL3: {R: 255, G: 0, B: 0},
+	LEVEL4: {R: 128, G: 64, B: 0},
+	LEVEL5: {R: 0, G: 0, B: 0},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Low",
+	LEVEL2: "Moderate",
+	LEVEL3: "High",
+	LEVEL4: "Very High",
+	LEVEL5: "Serious",
+}
+
+// AddedRisk returns the percentage added health risk of 3-hour moving average
+// value of p in μg/m3.
+func AddedRisk(p goaqi.Pollutant, value float64) float64 {
+	return (math.Exp(coefficients[p]*value) - 1) * 100
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "hk"
+}
+
+// Calc is func for AQHI computing from 3-hour moving average of NO2, SO2, O3
+// and PM10 or PM2.5. AQHI is from 1 to 10, or AQHIPlus above 10. There is no
+// primary pollutant of AQHI.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	risks := make(map[goaqi.Pollutant]float64)
+	var skipped []*goaqi.IAQI
+	for _, pollutantVar := range pollutantVars {
+		if _, ok := coefficients[pollutantVar.P]; !ok {
+			skipped = append(skipped, &goaqi.IAQI{Var: pollutantVar, Skipped: true, SkipReason: goaqi.SKIPREASON_UNSUPPORTED})
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return 0, nil, err
+		}
+		if err := goaqi.ValidateValue(value); err != nil {
+			return 0, nil, err
+		}
+		risks[pollutantVar.P] = AddedRisk(pollutantVar.P, value)
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(skipped); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	var total float64
+	for _, p := range []goaqi.Pollutant{goaqi.NO2_3H, goaqi.SO2_3H, goaqi.O3_3H} {
+		risk, ok := risks[p]
+		if !ok {
+			return 0, nil, fmt.Errorf("%w: %v", ErrMissingPollutant, p)
+		}
+		total += risk
+	}
+	pm10, ok10 := risks[goaqi.PM10_3H]
+	pm25, ok25 := risks[goaqi.PM2_5_3H]
+	if !ok10 && !ok25 {
+		return 0, nil, fmt.Errorf("%w: %v", ErrMissingPollutant, goaqi.PM10_3H)
+	}
+	total += math.Max(pm10, pm25)
+
+	for i, band := range bands {
+		if total <= band {
+			return i + 1, nil, nil
+		}
+	}
+	return AQHIPlus, nil, nil
+}
+
+// Scale returns the range of AQHI, where AQHIPlus means 10+.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 1, Max: AQHIPlus}
+}
+
+// ExpectedUnit returns the unit of p in the AQHI formula.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	if _, ok := coefficients[p]; !ok {
+		return goaqi.UNIT_UNSPECIFIED
+	}
+	return goaqi.UNIT_MIU_G_PER_M3
+}
+
+// AQIToLevel returns the health risk category of aqhi.
+func (a *Algo) AQIToLevel(aqhi int) AQILevel {
+	if aqhi <= 3 {
+		return LEVEL1
+	}
+	if aqhi <= 6 {
+		return LEVEL2
+	}
+	if aqhi <= 7 {
+		return LEVEL3
+	}
+	if aqhi <= 10 {
+		return LEVEL4
+	}
+	return LEVEL5
+}
+
+func (a *Algo) AQIToColor(aqhi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqhi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqhi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqhi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
@@ -0,0 +1,174 @@
+package hk_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/hk"
+)
+
+var (
+	_ goaqi.StandardWithColor = &hk.Algo{}
+	_ goaqi.StandardWithScale = &hk.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	algo := &hk.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.NO2_3H,
+			Value: 60,
+		},
+		{
+			P:     goaqi.SO2_3H,
+			Value: 10,
+		},
+		{
+			P:     goaqi.O3_3H,
+			Value: 50,
+		},
+		{
+			P:     goaqi.PM10_3H,
+			Value: 40,
+		},
+		{
+			P:     goaqi.PM2_5_3H,
+			Value: 25,
+		},
+	}
+	aqhi, _, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(aqhi)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("aqhi=%v as %v\n", aqhi, desc)
+	// Output: aqhi=4 as Moderate
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr error
+	}{
+		{
+			name: "clean air",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 0},
+					{P: goaqi.SO2_3H, Value: 0},
+					{P: goaqi.O3_3H, Value: 0},
+					{P: goaqi.PM2_5_3H, Value: 0},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "higher risk of pm2.5 and pm10",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 60},
+					{P: goaqi.SO2_3H, Value: 10},
+					{P: goaqi.O3_3H, Value: 50},
+					{P: goaqi.PM10_3H, Value: 40},
+					{P: goaqi.PM2_5_3H, Value: 100},
+				},
+			},
+			want: 5,
+		},
+		{
+			name: "10+",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 200},
+					{P: goaqi.SO2_3H, Value: 20},
+					{P: goaqi.O3_3H, Value: 200},
+					{P: goaqi.PM10_3H, Value: 150},
+				},
+			},
+			want: hk.AQHIPlus,
+		},
+		{
+			name: "missing pm",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 60},
+					{P: goaqi.SO2_3H, Value: 10},
+					{P: goaqi.O3_3H, Value: 50},
+				},
+			},
+			wantErr: hk.ErrMissingPollutant,
+		},
+		{
+			name: "NaN value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: math.NaN()},
+					{P: goaqi.SO2_3H, Value: 10},
+					{P: goaqi.O3_3H, Value: 50},
+					{P: goaqi.PM2_5_3H, Value: 100},
+				},
+			},
+			wantErr: goaqi.ErrNaNValue,
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.NO2_3H, Value: 60},
+					{P: goaqi.SO2_3H, Value: -1},
+					{P: goaqi.O3_3H, Value: 50},
+					{P: goaqi.PM2_5_3H, Value: 100},
+				},
+			},
+			wantErr: goaqi.ErrNegativeValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &hk.Algo{}
+			got, _, err := a.Calc(tt.args.pollutantVars...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("hk.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("hk.Calc() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgo_Calc_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.NO2_3H, Value: 50},
+		{P: goaqi.SO2_3H, Value: 10},
+		{P: goaqi.O3_3H, Value: 50},
+		{P: goaqi.PM10_3H, Value: 40},
+		unsupported,
+	}
+
+	if _, _, err := (&hk.Algo{}).Calc(inputs...); err != nil {
+		t.Fatalf("hk.Calc() error = %v", err)
+	}
+	_, _, err := (&hk.Algo{Strict: true}).Calc(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("hk.Calc() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,276 @@
+// Package india is for National Air Quality Index of India
+//
+// The index is published by Central Pollution Control Board (CPCB) in 2014,
+// with eight pollutants of 24-hour or 8-hour average.
+//
+// Offical Doc
+// https://cpcb.nic.in/National-Air-Quality-Index/
+package india
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_IN
+
+// tables are the CPCB breakpoints, with gaps between rows like 30 and 31 of
+// PM2.5. The Severe category has no upper concentration in the official doc,
+// the highest breakpoints are the commonly used extrapolation of it.
+var tables = map[goaqi.Pollutant]goaqi.Table{
+	goaqi.PM10_24H: { // μg/m3
+		{CLo: 0, CHi: 50, ILo: 0, IHi: 50},
+		{CLo: 51, CHi: 100, ILo: 51, IHi: 100},
+		{CLo: 101, CHi: 250, ILo: 101, IHi: 200},
+		{CLo: 251, CHi: 350, ILo: 201, IHi: 300},
+		{CLo: 351, CHi: 430, ILo: 301, IHi: 400},
+		{CLo: 430, CHi: 510, ILo: 401, IHi: 500},
+	},
+	goaqi.PM2_5_24H: { // μg/m3
+		{CLo: 0, CHi: 30, ILo: 0, IHi: 50},
+		{CLo: 31, CHi: 60, ILo: 51, IHi: 100},
+		{CLo: 61, CHi: 90, ILo: 101, IHi: 200},
+		{CLo: 91, CHi: 120, ILo: 201, IHi: 300},
+		{CLo: 121, CHi: 250, ILo: 301, IHi: 400},
+		{CLo: 250, CHi: 380, ILo: 401, IHi: 500},
+	},
+	goaqi.NO2_24H: { // μg/m3
+		{CLo: 0, CHi: 40, ILo: 0, IHi: 50},
+		{CLo: 41, CHi: 80, ILo: 51, IHi: 100},
+		{CLo: 81, CHi: 180, ILo: 101, IHi: 200},
+		{CLo: 181, CHi: 280, ILo: 201, IHi: 300},
+		{CLo: 281, CHi: 400, ILo: 301, IHi: 400},
+		{CLo: 400, CHi: 520, ILo: 401, IHi: 500},
+	},
+	goaqi.O3_8H: { // μg/m3
+		{CLo: 0, CHi: 50, ILo: 0, IHi: 50},
+		{CLo: 51, CHi: 100, ILo: 51, IHi: 100},
+		{CLo: 101, CHi: 168, ILo: 101, IHi: 200},
+		{CLo: 169, CHi: 208, ILo: 201, IHi: 300},
+		{CLo: 209, CHi: 748, ILo: 301, IHi: 400},
+		{CLo: 748, CHi: 1028, ILo: 401, IHi: 500},
+	},
+	goaqi.CO_8H: { // mg/m3
+		{CLo: 0, CHi: 1.0, ILo: 0, IHi: 50},
+		{CLo: 1.1, CHi: 2.0, ILo: 51, IHi: 100},
+		{CLo: 2.1, CHi: 10, ILo: 101, IHi: 200},
+		{CLo: 10, CHi: 17, ILo: 201, IHi: 300},
+		{CLo: 17, CHi: 34, ILo: 301, IHi: 400},
+		{CLo: 34, CHi: 51, ILo: 401, IHi: 500},
+	},
+	goaqi.SO2_24H: { // μg/m3
+		{CLo: 0, CHi: 40, ILo: 0, IHi: 50},
+		{CLo: 41, CHi: 80, ILo: 51, IHi: 100},
+		{CLo: 81, CHi: 380, ILo: 101, IHi: 200},
+		{CLo: 381, CHi: 800, ILo: 201, IHi: 300},
+		{CLo: 801, CHi: 1600, ILo: 301, IHi: 400},
+		{CLo: 1600, CHi: 2400, ILo: 401, IHi: 500},
+	},
+	goaqi.NH3_24H: { // μg/m3
+		{CLo: 0, CHi: 200, ILo: 0, IHi: 50},
+		{CLo: 201, CHi: 400, ILo: 51, IHi: 100},
+		{CLo: 401, CHi: 800, ILo: 101, IHi: 200},
+		{CLo: 801, CHi: 1200, ILo: 201, IHi: 300},
+		{CLo: 1200, CHi: 1800, ILo: 301, IHi: 400},
+		{CLo: 1800, CHi: 2400, ILo: 401, IHi: 500},
+	},
+	goaqi.PB_24H: { // μg/m3
+		{CLo: 0, CHi: 0.5, ILo: 0, IHi: 50},
+		{CLo: 0.5, CHi: 1.0, ILo: 51, IHi: 100},
+		{CLo: 1.1, CHi: 2.0, ILo: 101, IHi: 200},
+		{CLo: 2.1, CHi: 3.0, ILo: 201, IHi: 300},
+		{CLo: 3.1, CHi: 3.5, ILo: 301, IHi: 400},
+		{CLo: 3.5, CHi: 4.0, ILo: 401, IHi: 500},
+	},
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.PM10_24H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM2_5_24H: goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.NO2_24H:   goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.O3_8H:     goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.CO_8H:     goaqi.UNIT_MG_PER_M3,
+	goaqi.SO2_24H:   goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.NH3_24H:   goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PB_24H:    goaqi.UNIT_MIU_G_PER_M3,
+}
+
+// ErrInsufficientPollutants is returned if there are less than 3 pollutants,
+// or none of them is PM2.5 or PM10.
+var ErrInsufficientPollutants = errors.New("go-aqi: india requires at least 3 pollutants including PM2.5 or PM10")
+
+const minPollutants = 3
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+	LEVEL5
+	LEVEL6
+)
+
+var levelToAQIRange = map[AQILevel][2]int{
+	LEVEL1: {0, 50},
+	LEVEL2: {50, 100},
+	LEVEL3: {100, 200},
+	LEVEL4: {200, 300},
+	LEVEL5: {300, 400},
+	LEVEL6: {400, 500},
+}
+
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 0, G: 176, B: 80},
+	LEVEL2: {R: 146, G: 208, B: 80},
+	LEVEL3: {R: 255, G: 255, B: 0},
+	LEVEL4: {R: 255, G: 153, B: 0},
+	LEVEL5: {R: 255, G: 0, B: 0},
+	LEVEL6: {R: 192, G: 0, B: 0},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Good",
+	LEVEL2: "Satisfactory",
+	LEVEL3: "Moderate",
+	LEVEL4: "Poor",
+	LEVEL5: "Very Poor",
+	LEVEL6: "Severe",
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "india"
+}
+
+// Calc is func for AQI computing, which requires at least 3 pollutants
+// including PM2.5 or PM10.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns sub-index of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	var (
+		iaqis      = make([]*goaqi.IAQI, 0, len(pollutantVars))
+		pollutants = make(map[goaqi.Pollutant]bool)
+	)
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		table, ok := tables[pollutantVar.P]
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+
+		rawAQI, breakpoint, err := table.Calc(value)
+		if err != nil {
+			return nil, err
+		}
+		iaqi.Value, iaqi.RawValue, iaqi.Breakpoint, iaqi.Skipped = goaqi.RoundDown(rawAQI), rawAQI, breakpoint, false
+		pollutants[pollutantVar.P] = true
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	if len(pollutants) < minPollutants || !(pollutants[goaqi.PM2_5_24H] || pollutants[goaqi.PM10_24H]) {
+		return nil, ErrInsufficientPollutants
+	}
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundDown, 50, nil), nil
+}
+
+// Scale returns the range of AQI.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 0, Max: 500}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
+}
+
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= 50 {
+		return LEVEL1
+	}
+	if aqi <= 100 {
+		return LEVEL2
+	}
+	if aqi <= 200 {
+		return LEVEL3
+	}
+	if aqi <= 300 {
+		return LEVEL4
+	}
+	if aqi <= 400 {
+		return LEVEL5
+	}
+	return LEVEL6
+}
+
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
+
+// AQIToConcentration returns the lowest concentration of p, in the unit from
+// ExpectedUnit, whose sub-index is aqi.
+func (a *Algo) AQIToConcentration(p goaqi.Pollutant, aqi int) (float64, error) {
+	table, ok := tables[p]
+	if !ok {
+		return 0, fmt.Errorf("go-aqi: unsupported pollutant %v", p)
+	}
+	return table.Inverse(float64(aqi))
+}
+
+// LevelToConcentration returns the concentration range (lo, hi] of p, in the
+// unit from ExpectedUnit, whose sub-index falls in level.
+func (a *Algo) LevelToConcentration(p goaqi.Pollutant, level AQILevel) (lo float64, hi float64, err error) {
+	aqiRange, ok := levelToAQIRange[level]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown aqi level for concentration")
+	}
+	lo, err = a.AQIToConcentration(p, aqiRange[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err = a.AQIToConcentration(p, aqiRange[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return lo, hi, nil
+}
@@ -0,0 +1,218 @@
+package india_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/india"
+)
+
+var (
+	_ goaqi.StandardWithColor   = &india.Algo{}
+	_ goaqi.StandardWithDetail  = &india.Algo{}
+	_ goaqi.StandardWithInverse = &india.Algo{}
+	_ goaqi.StandardWithScale   = &india.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	algo := &india.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.PM2_5_24H,
+			Value: 75,
+		},
+		{
+			P:     goaqi.PM10_24H,
+			Value: 120,
+		},
+		{
+			P:     goaqi.NO2_24H,
+			Value: 50,
+		},
+	}
+	aqi, primaryPollutant, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(aqi)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("aqi=%v as level=%v with primary pollutant as %v\n", aqi, desc, primaryPollutant)
+	// Output: aqi=148 as level=Moderate with primary pollutant as [PM2_5_24H]
+}
+
+func ExampleAlgo_AQIToColor() {
+	algo := &india.Algo{}
+	rgba, err := algo.AQIToColor(33)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%v", rgba)
+	// Output: &{0 176 80 0}
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr error
+	}{
+		{
+			name: "example",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 120},
+					{P: goaqi.SO2_24H, Value: 20},
+					{P: goaqi.NH3_24H, Value: 900},
+					{P: goaqi.PB_24H, Value: 0.2},
+				},
+			},
+			want:  225,
+			want1: []goaqi.Pollutant{goaqi.NH3_24H},
+		},
+		{
+			name: "convert co to mg/m3",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 20},
+					{P: goaqi.CO_8H, Value: 1500, Unit: goaqi.UNIT_MIU_G_PER_M3},
+					{P: goaqi.O3_8H, Value: 40},
+				},
+			},
+			want:  72,
+			want1: []goaqi.Pollutant{goaqi.CO_8H},
+		},
+		{
+			name: "lower bound of segment",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 31},
+					{P: goaqi.PM10_24H, Value: 40},
+					{P: goaqi.NO2_24H, Value: 20},
+				},
+			},
+			want:  51,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_24H},
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: -1},
+					{P: goaqi.PM10_24H, Value: 40},
+					{P: goaqi.NO2_24H, Value: 20},
+				},
+			},
+			wantErr: goaqi.ErrNegativeValue,
+		},
+		{
+			name: "capped severe",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 999},
+					{P: goaqi.PM10_24H, Value: 300},
+					{P: goaqi.NO2_24H, Value: 50},
+				},
+			},
+			want:  500,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_24H},
+		},
+		{
+			name: "less than 3 pollutants",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 75},
+					{P: goaqi.NO2_24H, Value: 50},
+					{P: goaqi.PM2_5_1H, Value: 75},
+				},
+			},
+			wantErr: india.ErrInsufficientPollutants,
+		},
+		{
+			name: "without pm",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.SO2_24H, Value: 20},
+					{P: goaqi.NO2_24H, Value: 50},
+					{P: goaqi.O3_8H, Value: 40},
+				},
+			},
+			wantErr: india.ErrInsufficientPollutants,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &india.Algo{}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("india.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("india.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("india.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestAlgo_AQIToConcentration(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       goaqi.Pollutant
+		aqi     int
+		want    float64
+		wantErr bool
+	}{
+		{name: "lowest", p: goaqi.PM2_5_24H, aqi: 0, want: 0},
+		{name: "upper bound of segment", p: goaqi.PM2_5_24H, aqi: 50, want: 30},
+		{name: "lower bound of segment", p: goaqi.PM2_5_24H, aqi: 51, want: 31},
+		{name: "highest", p: goaqi.PM10_24H, aqi: 500, want: 510},
+		{name: "unsupported pollutant", p: goaqi.PM2_5_1H, aqi: 50, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &india.Algo{}
+			got, err := a.AQIToConcentration(tt.p, tt.aqi)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AQIToConcentration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("AQIToConcentration() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.PM2_5_24H, Value: 20},
+		{P: goaqi.NO2_24H, Value: 20},
+		{P: goaqi.O3_8H, Value: 20},
+		unsupported,
+	}
+
+	if _, err := (&india.Algo{}).CalcWithDetail(inputs...); err != nil {
+		t.Fatalf("india.CalcWithDetail() error = %v", err)
+	}
+	_, err := (&india.Algo{Strict: true}).CalcWithDetail(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("india.CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,217 @@
+// Package korea is for Comprehensive Air-quality Index (CAI) of Korea
+//
+// CAI is published by Korea Environment Corporation on AirKorea. Unlike most
+// standards, CAI is not just the highest sub-index: it is raised when several
+// pollutants are Unhealthy at the same time, see Aggregate.
+//
+// Offical Doc
+// https://www.airkorea.or.kr/eng
+package korea
+
+import (
+	"fmt"
+	"image/color"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_KR
+
+// tables are the CAI breakpoints of AirKorea, with gaps between rows like 15
+// and 16 of PM2.5.
+var tables = map[goaqi.Pollutant]goaqi.Table{
+	goaqi.SO2_1H: { // ppm
+		{CLo: 0, CHi: 0.02, ILo: 0, IHi: 50},
+		{CLo: 0.021, CHi: 0.05, ILo: 51, IHi: 100},
+		{CLo: 0.051, CHi: 0.15, ILo: 101, IHi: 250},
+		{CLo: 0.151, CHi: 1, ILo: 251, IHi: 500},
+	},
+	goaqi.CO_1H: { // ppm
+		{CLo: 0, CHi: 2, ILo: 0, IHi: 50},
+		{CLo: 2.01, CHi: 9, ILo: 51, IHi: 100},
+		{CLo: 9.01, CHi: 15, ILo: 101, IHi: 250},
+		{CLo: 15.01, CHi: 50, ILo: 251, IHi: 500},
+	},
+	goaqi.O3_1H: { // ppm
+		{CLo: 0, CHi: 0.03, ILo: 0, IHi: 50},
+		{CLo: 0.031, CHi: 0.09, ILo: 51, IHi: 100},
+		{CLo: 0.091, CHi: 0.15, ILo: 101, IHi: 250},
+		{CLo: 0.151, CHi: 0.6, ILo: 251, IHi: 500},
+	},
+	goaqi.NO2_1H: { // ppm
+		{CLo: 0, CHi: 0.03, ILo: 0, IHi: 50},
+		{CLo: 0.031, CHi: 0.06, ILo: 51, IHi: 100},
+		{CLo: 0.061, CHi: 0.2, ILo: 101, IHi: 250},
+		{CLo: 0.201, CHi: 2, ILo: 251, IHi: 500},
+	},
+	goaqi.PM10_24H: { // μg/m3
+		{CLo: 0, CHi: 30, ILo: 0, IHi: 50},
+		{CLo: 31, CHi: 80, ILo: 51, IHi: 100},
+		{CLo: 81, CHi: 150, ILo: 101, IHi: 250},
+		{CLo: 151, CHi: 600, ILo: 251, IHi: 500},
+	},
+	goaqi.PM2_5_24H: { // μg/m3
+		{CLo: 0, CHi: 15, ILo: 0, IHi: 50},
+		{CLo: 16, CHi: 35, ILo: 51, IHi: 100},
+		{CLo: 36, CHi: 75, ILo: 101, IHi: 250},
+		{CLo: 76, CHi: 500, ILo: 251, IHi: 500},
+	},
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.SO2_1H:    goaqi.UNIT_PPM,
+	goaqi.CO_1H:     goaqi.UNIT_PPM,
+	goaqi.O3_1H:     goaqi.UNIT_PPM,
+	goaqi.NO2_1H:    goaqi.UNIT_PPM,
+	goaqi.PM10_24H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM2_5_24H: goaqi.UNIT_MIU_G_PER_M3,
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+)
+
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 50, G: 161, B: 255},
+	LEVEL2: {R: 0, G: 199, B: 60},
+	LEVEL3: {R: 253, G: 155, B: 90},
+	LEVEL4: {R: 255, G: 89, B: 89},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Good",
+	LEVEL2: "Moderate",
+	LEVEL3: "Unhealthy",
+	LEVEL4: "Very Unhealthy",
+}
+
+// Penalties added to the highest sub-index, by the number of pollutants whose
+// sub-index is Unhealthy or worse.
+const (
+	penaltyTwo   = 50
+	penaltyThree = 75
+)
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "korea"
+}
+
+// Calc is func for realtime CAI computing.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns sub-index of every pollutant.
+//
+// Primary pollutants are the ones with the highest sub-index, the penalty of
+// Aggregate does not change them.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		table, ok := tables[pollutantVar.P]
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+
+		rawAQI, breakpoint, err := table.Calc(value)
+		if err != nil {
+			return nil, err
+		}
+		iaqi.Value, iaqi.RawValue, iaqi.Breakpoint, iaqi.Skipped = goaqi.RoundDown(rawAQI), rawAQI, breakpoint, false
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	return goaqi.NewResult(iaqis, Aggregate, goaqi.RoundDown, 50, nil), nil
+}
+
+// Aggregate is the goaqi.Aggregator of CAI. It returns the highest sub-index,
+// plus 50 if two pollutants are Unhealthy or worse, or plus 75 if three or
+// more are. The result is capped at 500.
+func Aggregate(iaqis []*goaqi.IAQI) float64 {
+	return min(goaqi.MaxRawValue(iaqis)+float64(penalty(iaqis)), 500)
+}
+
+// penalty returns the penalty of iaqis by the number of pollutants whose
+// sub-index is Unhealthy or worse.
+func penalty(iaqis []*goaqi.IAQI) int {
+	unhealthy := make(map[goaqi.Pollutant]bool)
+	for _, iaqi := range iaqis {
+		if !iaqi.Skipped && iaqi.Value > 100 {
+			unhealthy[iaqi.Var.P] = true
+		}
+	}
+	switch {
+	case len(unhealthy) >= 3:
+		return penaltyThree
+	case len(unhealthy) == 2:
+		return penaltyTwo
+	}
+	return 0
+}
+
+// Scale returns the range of CAI.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 0, Max: 500}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
+}
+
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= 50 {
+		return LEVEL1
+	}
+	if aqi <= 100 {
+		return LEVEL2
+	}
+	if aqi <= 250 {
+		return LEVEL3
+	}
+	return LEVEL4
+}
+
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
@@ -0,0 +1,187 @@
+package korea_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/korea"
+)
+
+var (
+	_ goaqi.StandardWithColor  = &korea.Algo{}
+	_ goaqi.StandardWithDetail = &korea.Algo{}
+	_ goaqi.StandardWithScale  = &korea.Algo{}
+
+	_ goaqi.Aggregator = korea.Aggregate
+)
+
+func ExampleAlgo_Calc() {
+	algo := &korea.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.PM10_24H,
+			Value: 60,
+		},
+		{
+			P:     goaqi.O3_1H,
+			Value: 0.02,
+		},
+	}
+	aqi, primaryPollutants, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(aqi)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("cai=%v as %v with primary pollutant as %v\n", aqi, desc, primaryPollutants)
+	// Output: cai=80 as Moderate with primary pollutant as [PM10_24H]
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr bool
+	}{
+		{
+			name: "one unhealthy",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 50},
+					{P: goaqi.PM10_24H, Value: 60},
+				},
+			},
+			want:  154,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_24H},
+		},
+		{
+			name: "two unhealthy",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 50},
+					{P: goaqi.PM10_24H, Value: 100},
+				},
+			},
+			want:  204,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_24H},
+		},
+		{
+			name: "three unhealthy",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 50},
+					{P: goaqi.PM10_24H, Value: 100},
+					{P: goaqi.CO_1H, Value: 12},
+				},
+			},
+			want:  250,
+			want1: []goaqi.Pollutant{goaqi.CO_1H},
+		},
+		{
+			name: "lower bound of moderate",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 16},
+				},
+			},
+			want:  51,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_24H},
+		},
+		{
+			name: "two at lower bound of unhealthy",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 36},
+					{P: goaqi.PM10_24H, Value: 81},
+				},
+			},
+			want:  151,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_24H, goaqi.PM10_24H},
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: -1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "converted from μg/m3",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.CO_1H, Value: 14000, Unit: goaqi.UNIT_MIU_G_PER_M3},
+				},
+			},
+			want:  180,
+			want1: []goaqi.Pollutant{goaqi.CO_1H},
+		},
+		{
+			name: "zero",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.CO_1H, Value: 0},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "capped",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 600},
+					{P: goaqi.PM10_24H, Value: 700},
+				},
+			},
+			want:  500,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_24H, goaqi.PM10_24H},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &korea.Algo{}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("korea.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("korea.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("korea.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.PM2_5_1H, Value: 10}
+	inputs := []*goaqi.Var{
+		{P: goaqi.PM2_5_24H, Value: 10},
+		unsupported,
+	}
+
+	if _, err := (&korea.Algo{}).CalcWithDetail(inputs...); err != nil {
+		t.Fatalf("korea.CalcWithDetail() error = %v", err)
+	}
+	_, err := (&korea.Algo{Strict: true}).CalcWithDetail(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("korea.CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
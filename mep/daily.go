@@ -0,0 +1,134 @@
+package mep
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const (
+	// 日最大 1 小时平均和 24 小时平均至少需要 20 个有效小时
+	minValidHoursOfDay = 20
+
+	// 日最大 8 小时滑动平均至少需要 14 个有效 8 小时平均
+	minValid8HOfDay = 14
+)
+
+// CalcDaily is func for daily AQI report computing from hourly observations of
+// day, which covers hours from 00:00 to 23:00 in the location of day.
+//
+// SO2, NO2, CO, PM10 and PM2.5 use 24-hour average, O3 uses daily max 1-hour
+// average and daily max 8-hour moving average. Pollutants without enough valid
+// hours are left out.
+//
+// CalcDaily 计算策略是 HJ633-2012 中的日报，其中 SO2、NO2、CO、PM10、PM2.5 采用 24
+// 小时平均，O3 采用日最大 1 小时平均和日最大 8 小时滑动平均
+func (a *Algo) CalcDaily(day time.Time, observations ...*goaqi.Observation) (*goaqi.Result, error) {
+	var (
+		start         = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+		lastHour      = start.Add(23 * time.Hour)
+		pollutantVars = make([]*goaqi.Var, 0)
+	)
+
+	for _, w := range Windows {
+		if w.Hours != 24 {
+			continue
+		}
+		pollutantVar, err := goaqi.MovingAverage(w, lastHour, observations...)
+		if errors.Is(err, goaqi.ErrInsufficientData) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pollutantVars = append(pollutantVars, pollutantVar)
+	}
+
+	o3 := make([]*goaqi.Observation, 0)
+	for _, observation := range observations {
+		if observation.P == goaqi.O3_1H {
+			o3 = append(o3, observation)
+		}
+	}
+
+	max1H, err := dailyMax1H(lastHour, o3...)
+	if err != nil {
+		return nil, err
+	}
+	if max1H != nil {
+		pollutantVars = append(pollutantVars, max1H)
+	}
+
+	max8H, err := dailyMax8H(start, o3...)
+	if err != nil {
+		return nil, err
+	}
+	if max8H != nil {
+		pollutantVars = append(pollutantVars, max8H)
+	}
+
+	return a.CalcWithDetail(pollutantVars...)
+}
+
+// dailyMax1H returns daily max 1-hour average of O3, nil if not enough hours.
+func dailyMax1H(lastHour time.Time, observations ...*goaqi.Observation) (*goaqi.Var, error) {
+	unit := units[goaqi.O3_1H]
+	values, err := goaqi.HourlyValues(observations, goaqi.O3_1H, unit, lastHour, 24)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		maxValue = math.Inf(-1)
+		valid    int
+	)
+	for _, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		maxValue = math.Max(maxValue, value)
+		valid++
+	}
+	if valid < minValidHoursOfDay {
+		return nil, nil
+	}
+	return &goaqi.Var{P: goaqi.O3_1H, Value: maxValue, Unit: unit}, nil
+}
+
+// dailyMax8H returns daily max 8-hour moving average of O3, from windows of
+// 00:00-08:00 to 16:00-24:00, nil if not enough windows.
+func dailyMax8H(start time.Time, observations ...*goaqi.Observation) (*goaqi.Var, error) {
+	var (
+		w     = window(goaqi.O3_8H)
+		max8H *goaqi.Var
+		valid int
+	)
+	for end := start.Add(7 * time.Hour); end.Before(start.Add(24 * time.Hour)); end = end.Add(time.Hour) {
+		pollutantVar, err := goaqi.MovingAverage(w, end, observations...)
+		if errors.Is(err, goaqi.ErrInsufficientData) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("go-aqi: daily max 8-hour O3: %w", err)
+		}
+		valid++
+		if max8H == nil || pollutantVar.Value > max8H.Value {
+			max8H = pollutantVar
+		}
+	}
+	if valid < minValid8HOfDay {
+		return nil, nil
+	}
+	return max8H, nil
+}
+
+func window(p goaqi.Pollutant) goaqi.Window {
+	for _, w := range Windows {
+		if w.To == p {
+			return w
+		}
+	}
+	return goaqi.Window{}
+}
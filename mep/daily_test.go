@@ -0,0 +1,109 @@
+package mep_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/mep"
+)
+
+func hourly(p goaqi.Pollutant, start time.Time, values ...float64) []*goaqi.Observation {
+	observations := make([]*goaqi.Observation, 0, len(values))
+	for i, value := range values {
+		observations = append(observations, &goaqi.Observation{
+			Var:  goaqi.Var{P: p, Value: value},
+			Time: start.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	return observations
+}
+
+func repeat(value float64, n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
+
+func ExampleAlgo_CalcDaily() {
+	algo := &mep.Algo{}
+	day := time.Date(2024, 7, 1, 0, 0, 0, 0, time.FixedZone("CST", 8*3600))
+
+	observations := make([]*goaqi.Observation, 0)
+	observations = append(observations, hourly(goaqi.PM10_1H, day, repeat(100, 24)...)...)
+	observations = append(observations, hourly(goaqi.PM2_5_1H, day, repeat(50, 24)...)...)
+	o3 := append(append(repeat(100, 12), repeat(250, 8)...), repeat(100, 4)...)
+	observations = append(observations, hourly(goaqi.O3_1H, day, o3...)...)
+
+	result, err := algo.CalcDaily(day, observations...)
+	if err != nil {
+		panic(err)
+	}
+	level, err := algo.AQIToDesc(result.AQI)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("aqi=%v as level=%v with primary pollutant as %v\n", result.AQI, level, result.PrimaryPollutants)
+	// Output: aqi=185 as level=中度污染 with primary pollutant as [O3_8H]
+}
+
+func TestAlgo_CalcDaily(t *testing.T) {
+	day := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		observations []*goaqi.Observation
+		want         []*goaqi.IAQI
+	}{
+		{
+			name: "24-hour average",
+			observations: append(
+				hourly(goaqi.SO2_1H, day, repeat(50, 24)...),
+				hourly(goaqi.CO_1H, day.Add(-time.Hour), repeat(14, 2)...)...,
+			),
+			want: []*goaqi.IAQI{
+				{
+					Var:        &goaqi.Var{P: goaqi.SO2_24H, Value: 50},
+					Value:      50,
+					RawValue:   50,
+					Breakpoint: &goaqi.Breakpoint{CLo: 0, CHi: 50, ILo: 0, IHi: 50},
+				},
+			},
+		},
+		{
+			name:         "not enough hours",
+			observations: hourly(goaqi.PM2_5_1H, day, repeat(50, 19)...),
+			want:         []*goaqi.IAQI{},
+		},
+		{
+			name: "not enough 8-hour windows of O3",
+			observations: append(
+				hourly(goaqi.O3_1H, day, repeat(100, 8)...),
+				hourly(goaqi.O3_1H, day.Add(12*time.Hour), repeat(100, 12)...)...,
+			),
+			want: []*goaqi.IAQI{
+				{
+					Var:        &goaqi.Var{P: goaqi.O3_1H, Value: 100, Unit: goaqi.UNIT_MIU_G_PER_M3},
+					Value:      32,
+					RawValue:   31.25,
+					Breakpoint: &goaqi.Breakpoint{CLo: 0, CHi: 160, ILo: 0, IHi: 50},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &mep.Algo{}
+			got, err := a.CalcDaily(day, tt.observations...)
+			if err != nil {
+				t.Fatalf("mep.CalcDaily() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.IAQIs, tt.want) {
+				t.Errorf("mep.CalcDaily() got = %+v, want %+v", got.IAQIs, tt.want)
+			}
+		})
+	}
+}
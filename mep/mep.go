@@ -32,6 +32,39 @@ var tables = map[goaqi.Pollutant][]float64{
 	goaqi.PM2_5_24H: {0, 35, 75, 115, 150, 250, 350, 500},       // μg/m3
 }
 
+// Windows are moving averages from hourly data in HJ 663-2013, which needs at
+// least 20 valid hours for 24-hour average and 6 valid hours for 8-hour
+// average of O3.
+var Windows = []goaqi.Window{
+	{From: goaqi.SO2_1H, To: goaqi.SO2_24H, Hours: 24, MinValid: 20},
+	{From: goaqi.NO2_1H, To: goaqi.NO2_24H, Hours: 24, MinValid: 20},
+	{From: goaqi.CO_1H, To: goaqi.CO_24H, Hours: 24, MinValid: 20},
+	{From: goaqi.O3_1H, To: goaqi.O3_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.PM10_1H, To: goaqi.PM10_24H, Hours: 24, MinValid: 20},
+	{From: goaqi.PM2_5_1H, To: goaqi.PM2_5_24H, Hours: 24, MinValid: 20},
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.CO_1H:     goaqi.UNIT_MG_PER_M3,
+	goaqi.CO_24H:    goaqi.UNIT_MG_PER_M3,
+	goaqi.SO2_24H:   goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.SO2_1H:    goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.NO2_24H:   goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.NO2_1H:    goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.O3_1H:     goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.O3_8H:     goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM10_1H:   goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM10_24H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM2_5_1H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM2_5_24H: goaqi.UNIT_MIU_G_PER_M3,
+}
+
+// substitutes are pollutants used instead of those out of their domain.
+var substitutes = map[goaqi.Pollutant]goaqi.Pollutant{
+	goaqi.SO2_1H: goaqi.SO2_24H,
+	goaqi.O3_8H:  goaqi.O3_1H,
+}
+
 type AQILevel int
 
 const (
@@ -44,6 +77,15 @@ const (
 	LEVEL6
 )
 
+var levelToAQIRange = map[AQILevel][2]int{
+	LEVEL1: {0, 50},
+	LEVEL2: {50, 100},
+	LEVEL3: {100, 150},
+	LEVEL4: {150, 200},
+	LEVEL5: {200, 300},
+	LEVEL6: {300, 500},
+}
+
 var levelToColor = map[AQILevel]*color.RGBA{
 	LEVEL1: {R: 0, G: 255, B: 0},
 	LEVEL2: {R: 255, G: 255, B: 0},
@@ -62,7 +104,12 @@ var levelToDesc = map[AQILevel]string{
 	LEVEL6: "严重污染",
 }
 
-type Algo struct{}
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants and values
+	// out of their domain, like 8-hour O3 above 800 μg/m3, instead of skipping
+	// them silently.
+	Strict bool
+}
 
 func (a *Algo) Name() string {
 	return "mep"
@@ -70,59 +117,69 @@ func (a *Algo) Name() string {
 
 // Calc is func for realtime AQI report computing, using PM2.5 1H, PM10 1H.
 //
-// Calc 计算策略是 HJ633-2012 中的实时报，其中采用 PM2.5 1H, PM10 1H 变量计算
+// Calc 计算策略是 HJ633-2012 中的实时报，其中采用 PM2.5 1H, PM10 1H 变量计算，
+// IAQI 和 AQI 按要求进位取整。
 func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
-	var (
-		results = make(map[goaqi.Pollutant]int)
-		maxAQI  int
-	)
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns sub-index of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
 
 	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
 		pollutantIndexRange, ok := tables[pollutantVar.P]
 		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
 			continue
 		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
 
 		// 二氧化硫（SO2）1 小时平均浓度值高于 800 μg/m 3的，不再进行其空气质量分指数计算；
 		// 二氧化硫（SO2） 空气质量分指数按 24 小时平均浓度计算的分指数报告。
-		if pollutantVar.P == goaqi.SO2_1H && pollutantVar.Value > 800 {
+		if pollutantVar.P == goaqi.SO2_1H && value > 800 {
+			iaqi.SkipReason = goaqi.SKIPREASON_OUT_OF_DOMAIN
 			continue
 		}
 		// 臭氧（O3）8 小时平均浓度值高于 800 μg/m 3的，不再进行其空气质量分指数计算；
 		// 臭氧（O3）空气质量分指数按 1 小时平均浓度计算的分指数报告。
-		if pollutantVar.P == goaqi.O3_8H && pollutantVar.Value > 800 {
+		if pollutantVar.P == goaqi.O3_8H && value > 800 {
+			iaqi.SkipReason = goaqi.SKIPREASON_OUT_OF_DOMAIN
 			continue
 		}
 
-		aqi, err := func() (int, error) {
-			if pollutantVar.Value > pollutantIndexRange[len(pollutantIndexRange)-1] {
-				return 500, nil
-			}
-			iaqiLo, iaqiHi, pLo, pHi, err := goaqi.GetRanges(pollutantVar.Value, pollutantIndexRange, tables[goaqi.AQI])
-			if err != nil {
-				return 0, err
-			}
-			return goaqi.CalcViaHiLo(pollutantVar.Value, iaqiLo, iaqiHi, pLo, pHi)
-		}()
-
+		rawAQI, breakpoint, err := goaqi.CalcIAQI(value, pollutantIndexRange, tables[goaqi.AQI])
 		if err != nil {
-			return 0, nil, err
+			return nil, err
 		}
-		if aqi > maxAQI {
-			maxAQI = aqi
-		}
-		results[pollutantVar.P] = aqi
-	}
-	if maxAQI <= 50 {
-		return maxAQI, nil, nil
+		iaqi.Value, iaqi.RawValue, iaqi.Breakpoint, iaqi.Skipped = goaqi.RoundUp(rawAQI), rawAQI, breakpoint, false
 	}
-	primaryPollutants := make([]goaqi.Pollutant, 0)
-	for pollutant, value := range results {
-		if value == maxAQI {
-			primaryPollutants = append(primaryPollutants, pollutant)
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
 		}
 	}
-	return maxAQI, primaryPollutants, nil
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundUp, 50, substitutes), nil
+}
+
+// Scale returns the range of AQI.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 0, Max: 500}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
 }
 
 func (a *Algo) AQIToLevel(aqi int) AQILevel {
@@ -159,3 +216,32 @@ func (a *Algo) AQIToDesc(aqi int) (string, error) {
 	}
 	return desc, nil
 }
+
+// AQIToConcentration returns the lowest concentration of p, in the unit from
+// ExpectedUnit, whose sub-index is aqi.
+func (a *Algo) AQIToConcentration(p goaqi.Pollutant, aqi int) (float64, error) {
+	pollutantIndexRange, ok := tables[p]
+	if !ok {
+		return 0, fmt.Errorf("go-aqi: unsupported pollutant %v", p)
+	}
+	return goaqi.NewTable(pollutantIndexRange, tables[goaqi.AQI]).Inverse(float64(aqi))
+}
+
+// LevelToConcentration returns the concentration range (lo, hi] of p, in the
+// unit from ExpectedUnit, whose sub-index falls in level. Like how much PM2.5
+// must drop to reach LEVEL2, or legends in native units.
+func (a *Algo) LevelToConcentration(p goaqi.Pollutant, level AQILevel) (lo float64, hi float64, err error) {
+	aqiRange, ok := levelToAQIRange[level]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown aqi level for concentration")
+	}
+	lo, err = a.AQIToConcentration(p, aqiRange[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err = a.AQIToConcentration(p, aqiRange[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return lo, hi, nil
+}
@@ -4,12 +4,18 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	goaqi "github.com/ringsaturn/go-aqi"
 	"github.com/ringsaturn/go-aqi/mep"
 )
 
-var _ goaqi.StandardWithColor = &mep.Algo{}
+var (
+	_ goaqi.StandardWithColor   = &mep.Algo{}
+	_ goaqi.StandardWithDetail  = &mep.Algo{}
+	_ goaqi.StandardWithInverse = &mep.Algo{}
+	_ goaqi.StandardWithScale   = &mep.Algo{}
+)
 
 func ExampleAlgo_Calc() {
 	algo := &mep.Algo{}
@@ -47,6 +53,27 @@ func ExampleAlgo_Calc() {
 	// Output: aqi=69 with primary pollutant as [PM10_1H]
 }
 
+func ExampleAlgo_CalcWithDetail() {
+	algo := &mep.Algo{}
+	result, err := algo.CalcWithDetail(
+		&goaqi.Var{P: goaqi.PM2_5_1H, Value: 16},
+		&goaqi.Var{P: goaqi.PM10_1H, Value: 88},
+		&goaqi.Var{P: goaqi.CO_8H, Value: 0.2},
+	)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("aqi=%v with primary pollutant as %v\n", result.AQI, result.PrimaryPollutants)
+	for _, iaqi := range result.IAQIs {
+		fmt.Printf("%v iaqi=%v breakpoint=%+v skipped=%v\n", iaqi.Var.P, iaqi.Value, iaqi.Breakpoint, iaqi.Skipped)
+	}
+	// Output:
+	// aqi=69 with primary pollutant as [PM10_1H]
+	// PM2_5_1H iaqi=23 breakpoint=&{CLo:0 CHi:35 ILo:0 IHi:50} skipped=false
+	// PM10_1H iaqi=69 breakpoint=&{CLo:50 CHi:150 ILo:50 IHi:100} skipped=false
+	// CO_8H iaqi=0 breakpoint=<nil> skipped=true
+}
+
 func ExampleAlgo_AQIToColor() {
 	algo := &mep.Algo{}
 	rgba, err := algo.AQIToColor(33)
@@ -69,7 +96,7 @@ func ExampleAlgo_AQIToDesc() {
 
 func TestAlgo_Calc(t *testing.T) {
 	type fields struct {
-		FailedWhenNotSupported bool
+		Strict bool
 	}
 	type args struct {
 		pollutantVars []*goaqi.Var
@@ -85,7 +112,7 @@ func TestAlgo_Calc(t *testing.T) {
 		{
 			name: "example",
 			fields: fields{
-				FailedWhenNotSupported: false,
+				Strict: false,
 			},
 			args: args{
 				pollutantVars: []*goaqi.Var{
@@ -119,10 +146,113 @@ func TestAlgo_Calc(t *testing.T) {
 			want1:   []goaqi.Pollutant{goaqi.PM10_1H},
 			wantErr: false,
 		},
+		{
+			name: "primary pollutants in input order",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_1H, Value: 250},
+					{P: goaqi.PM2_5_1H, Value: 115},
+				},
+			},
+			want:    150,
+			want1:   []goaqi.Pollutant{goaqi.PM10_1H, goaqi.PM2_5_1H},
+			wantErr: false,
+		},
+		{
+			name: "primary pollutants in reversed input order",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_1H, Value: 115},
+					{P: goaqi.PM10_1H, Value: 250},
+				},
+			},
+			want:    150,
+			want1:   []goaqi.Pollutant{goaqi.PM2_5_1H, goaqi.PM10_1H},
+			wantErr: false,
+		},
+		{
+			name: "convert units",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_1H, Value: 0.088, Unit: goaqi.UNIT_MG_PER_M3},
+					{P: goaqi.CO_1H, Value: 20, Unit: goaqi.UNIT_PPM},
+				},
+			},
+			want:    126,
+			want1:   []goaqi.Pollutant{goaqi.CO_1H},
+			wantErr: false,
+		},
+		{
+			name: "convert actual state to reference state",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{
+						P:         goaqi.PM10_1H,
+						Value:     88,
+						Unit:      goaqi.UNIT_MIU_G_PER_M3,
+						Condition: &goaqi.StandardCondition,
+					},
+					{
+						P:         goaqi.NO2_1H,
+						Value:     200,
+						Unit:      goaqi.UNIT_MIU_G_PER_M3,
+						Condition: &goaqi.StandardCondition,
+					},
+				},
+			},
+			want:    92,
+			want1:   []goaqi.Pollutant{goaqi.NO2_1H},
+			wantErr: false,
+		},
+		{
+			name: "round up",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_1H, Value: 34},
+				},
+			},
+			want:    49,
+			want1:   nil,
+			wantErr: false,
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_1H, Value: -1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "skip 8-hour O3 above 800",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 900},
+					{P: goaqi.O3_1H, Value: 900},
+				},
+			},
+			want:    350,
+			want1:   []goaqi.Pollutant{goaqi.O3_1H},
+			wantErr: false,
+		},
+		{
+			name: "strict on 8-hour O3 above 800",
+			fields: fields{
+				Strict: true,
+			},
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 900},
+					{P: goaqi.O3_1H, Value: 900},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			a := &mep.Algo{}
+			a := &mep.Algo{Strict: tt.fields.Strict}
 			got, got1, err := a.Calc(tt.args.pollutantVars...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("goaqi.Calc() error = %v, wantErr %v", err, tt.wantErr)
@@ -138,6 +268,84 @@ func TestAlgo_Calc(t *testing.T) {
 	}
 }
 
+func TestAlgo_CalcWithDetail_warnings(t *testing.T) {
+	so2_1h := &goaqi.Var{P: goaqi.SO2_1H, Value: 900}
+	pm25_1h := &goaqi.Var{P: goaqi.PM2_5_1H, Value: 40}
+
+	result, err := (&mep.Algo{}).CalcWithDetail(so2_1h, pm25_1h)
+	if err != nil {
+		t.Fatalf("CalcWithDetail() error = %v", err)
+	}
+	want := []*goaqi.Warning{{Kind: goaqi.WARNINGKIND_MISSING_SUBSTITUTE, Var: so2_1h, Substitute: goaqi.SO2_24H}}
+	if !reflect.DeepEqual(result.Warnings, want) {
+		t.Errorf("CalcWithDetail() Warnings = %v, want %v", result.Warnings, want)
+	}
+}
+
+func ExampleAlgo_LevelToConcentration() {
+	algo := &mep.Algo{}
+	lo, hi, err := algo.LevelToConcentration(goaqi.PM2_5_1H, mep.LEVEL2)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("(%v, %v]", lo, hi)
+	// Output: (35, 75]
+}
+
+func TestAlgo_AQIToConcentration(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       goaqi.Pollutant
+		aqi     int
+		want    float64
+		wantErr bool
+	}{
+		{name: "inside segment", p: goaqi.PM10_1H, aqi: 69, want: 88},
+		{name: "boundary", p: goaqi.CO_24H, aqi: 200, want: 24},
+		{name: "undefined for 1-hour SO2", p: goaqi.SO2_1H, aqi: 250, wantErr: true},
+		{name: "above 500", p: goaqi.PM10_1H, aqi: 501, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &mep.Algo{}
+			got, err := a.AQIToConcentration(tt.p, tt.aqi)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AQIToConcentration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("AQIToConcentration() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAverager(t *testing.T) {
+	end := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	averager := goaqi.NewAverager(mep.Windows...)
+	for i := 0; i < 30; i++ {
+		hour := end.Add(-time.Duration(i) * time.Hour)
+		averager.Add(&goaqi.Observation{Var: goaqi.Var{P: goaqi.PM2_5_1H, Value: float64(i)}, Time: hour})
+		if i < 20 {
+			averager.Add(&goaqi.Observation{Var: goaqi.Var{P: goaqi.NO2_1H, Value: 40}, Time: hour})
+		}
+		if i < 5 {
+			averager.Add(&goaqi.Observation{Var: goaqi.Var{P: goaqi.O3_1H, Value: 100}, Time: hour})
+		}
+	}
+
+	got, err := averager.At(end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*goaqi.Var{
+		{P: goaqi.NO2_24H, Value: 40},
+		{P: goaqi.PM2_5_24H, Value: 11.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Averager.At() got = %+v, want %+v", got, want)
+	}
+}
+
 func BenchmarkAlgoCalc(b *testing.B) {
 	algo := &mep.Algo{}
 	inputs := []*goaqi.Var{
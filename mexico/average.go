@@ -0,0 +1,77 @@
+package mexico
+
+import (
+	"fmt"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+// Windows are moving averages of NOM-172-SEMARNAT-2019 for O3, CO and SO2,
+// which are valid with 6 of 8 or 18 of 24 hours.
+var Windows = []goaqi.Window{
+	{From: goaqi.O3_1H, To: goaqi.O3_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.CO_1H, To: goaqi.CO_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.SO2_1H, To: goaqi.SO2_24H, Hours: 24, MinValid: 18},
+}
+
+// weightedAverages maps hourly PM to its 12-hour weighted moving average.
+var weightedAverages = map[goaqi.Pollutant]goaqi.Pollutant{
+	goaqi.PM10_1H:  goaqi.PM10_12H,
+	goaqi.PM2_5_1H: goaqi.PM2_5_12H,
+}
+
+const (
+	weightedAverageHours     = 12
+	weightedAverageMinWeight = 0.5
+)
+
+// WeightedAverage returns the 12-hour weighted moving average at the hour of
+// end from hourly observations of p, which should be PM10_1H or PM2_5_1H.
+//
+// The weight factor is computed like NowCast with a minimum of 0.5, and at
+// least 2 of the 3 most recent hours must be valid. The returned Var is
+// PM10_12H or PM2_5_12H in μg/m3, ready to pass to Calc.
+func WeightedAverage(p goaqi.Pollutant, end time.Time, observations ...*goaqi.Observation) (*goaqi.Var, error) {
+	to, ok := weightedAverages[p]
+	if !ok {
+		return nil, fmt.Errorf("go-aqi: weighted average not supported for %v", p)
+	}
+	unit := units[to]
+	values, err := goaqi.HourlyValues(observations, p, unit, end, weightedAverageHours)
+	if err != nil {
+		return nil, err
+	}
+	value, err := goaqi.NowCast(values, weightedAverageMinWeight)
+	if err != nil {
+		return nil, fmt.Errorf("go-aqi: weighted average of %v at %v: %w", p, end, err)
+	}
+	return &goaqi.Var{P: to, Value: value, Unit: unit}, nil
+}
+
+// CalcAt computes the index at the hour of end from hourly observations. PM
+// uses WeightedAverage, CO, SO2 and 8-hour O3 use Windows, while 1-hour O3 and
+// NO2 use the observation of that hour as is.
+//
+// Pollutants without enough hours for their average are ignored.
+func (a *Algo) CalcAt(end time.Time, observations ...*goaqi.Observation) (int, []goaqi.Pollutant, error) {
+	pollutantVars, err := goaqi.VarsAt(observations, end, supported, average)
+	if err != nil {
+		return 0, nil, err
+	}
+	return a.Calc(pollutantVars...)
+}
+
+// supported reports whether p is computed by Calc.
+func supported(p goaqi.Pollutant) bool {
+	_, ok := tables[p]
+	return ok
+}
+
+// average is the goaqi.AverageFunc of Mexico.
+func average(p goaqi.Pollutant, end time.Time, observations ...*goaqi.Observation) (*goaqi.Var, error) {
+	if _, ok := weightedAverages[p]; ok {
+		return WeightedAverage(p, end, observations...)
+	}
+	return goaqi.WindowAverage(Windows, p, end, observations...)
+}
@@ -0,0 +1,71 @@
+package mexico_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/mexico"
+)
+
+func ExampleAlgo_CalcAt() {
+	algo := &mexico.Algo{}
+	end := time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)
+	observations := goaqi.HourlyObservations(goaqi.PM2_5_1H, end, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30)
+	observations = append(observations, goaqi.HourlyObservations(goaqi.O3_1H, end, 0.08, 0.08, 0.08, 0.08, 0.08, 0.08, 0.08, 0.08)...)
+
+	category, primaryPollutants, err := algo.CalcAt(end, observations...)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("category=%v with primary pollutant as %v\n", category, primaryPollutants)
+	// Output: category=3 with primary pollutant as [O3_8H]
+}
+
+func TestWeightedAverage(t *testing.T) {
+	end := time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)
+	nan := math.NaN()
+	tests := []struct {
+		name         string
+		p            goaqi.Pollutant
+		observations []*goaqi.Observation
+		want         *goaqi.Var
+		wantErr      error
+	}{
+		{
+			name:         "stable",
+			p:            goaqi.PM2_5_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM2_5_1H, end, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20),
+			want:         &goaqi.Var{P: goaqi.PM2_5_12H, Value: 20, Unit: goaqi.UNIT_MIU_G_PER_M3},
+		},
+		{
+			name:         "minimum weight factor",
+			p:            goaqi.PM10_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM10_1H, end, 10, 40),
+			want:         &goaqi.Var{P: goaqi.PM10_12H, Value: 20, Unit: goaqi.UNIT_MIU_G_PER_M3},
+		},
+		{
+			name:         "insufficient recent hours",
+			p:            goaqi.PM10_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM10_1H, end, nan, nan, 10, 10),
+			wantErr:      goaqi.ErrInsufficientData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mexico.WeightedAverage(tt.p, end, tt.observations...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mexico.WeightedAverage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.P != tt.want.P || got.Unit != tt.want.Unit || math.Abs(got.Value-tt.want.Value) > 1e-9 {
+				t.Errorf("mexico.WeightedAverage() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
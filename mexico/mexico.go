@@ -0,0 +1,174 @@
+// Package mexico is for Índice AIRE y SALUD of Mexico
+//
+// The index is defined by NOM-172-SEMARNAT-2019, which reports one of five
+// categories from Buena to Extremadamente Mala rather than a number.
+//
+// PM uses the 12-hour weighted moving average, O3 both the 1-hour value and the
+// 8-hour moving average, see WeightedAverage and Windows.
+//
+// Offical Doc
+// https://www.dof.gob.mx/nota_detalle.php?codigo=5579387&fecha=20/11/2019
+package mexico
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_MX
+
+// tables are upper bounds of category 1 to 4, values above the last one are
+// category 5.
+var tables = map[goaqi.Pollutant][]float64{
+	goaqi.O3_1H:     {0, 0.051, 0.095, 0.135, 0.175}, // ppm
+	goaqi.O3_8H:     {0, 0.051, 0.070, 0.092, 0.114}, // ppm
+	goaqi.NO2_1H:    {0, 0.107, 0.210, 0.230, 0.250}, // ppm
+	goaqi.SO2_24H:   {0, 0.008, 0.110, 0.165, 0.220}, // ppm
+	goaqi.CO_8H:     {0, 8.75, 11.00, 13.30, 15.50},  // ppm
+	goaqi.PM10_12H:  {0, 50, 75, 155, 235},           // μg/m3
+	goaqi.PM2_5_12H: {0, 25, 45, 79, 147},            // μg/m3
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.O3_1H:     goaqi.UNIT_PPM,
+	goaqi.O3_8H:     goaqi.UNIT_PPM,
+	goaqi.NO2_1H:    goaqi.UNIT_PPM,
+	goaqi.SO2_24H:   goaqi.UNIT_PPM,
+	goaqi.CO_8H:     goaqi.UNIT_PPM,
+	goaqi.PM10_12H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM2_5_12H: goaqi.UNIT_MIU_G_PER_M3,
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+	LEVEL5
+)
+
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 0, G: 228, B: 0},
+	LEVEL2: {R: 255, G: 255, B: 0},
+	LEVEL3: {R: 255, G: 126, B: 0},
+	LEVEL4: {R: 255, G: 0, B: 0},
+	LEVEL5: {R: 143, G: 63, B: 151},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Buena",
+	LEVEL2: "Aceptable",
+	LEVEL3: "Mala",
+	LEVEL4: "Muy Mala",
+	LEVEL5: "Extremadamente Mala",
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "mexico"
+}
+
+// Calc is func for hourly index computing, which returns the category from 1
+// as Buena to 5 as Extremadamente Mala, the worst category of all pollutants.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns category of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		pollutantIndexRange, ok := tables[pollutantVar.P]
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+		if err := goaqi.ValidateValue(value); err != nil {
+			return nil, err
+		}
+
+		category := int(LEVEL5)
+		for i := 1; i < len(pollutantIndexRange); i++ {
+			if value <= pollutantIndexRange[i] {
+				category = i
+				break
+			}
+		}
+		iaqi.Value, iaqi.RawValue, iaqi.Skipped = category, float64(category), false
+		iaqi.Breakpoint = &goaqi.Breakpoint{
+			CLo: pollutantIndexRange[category-1],
+			CHi: math.Inf(1),
+			ILo: float64(category),
+			IHi: float64(category),
+		}
+		if category < int(LEVEL5) {
+			iaqi.Breakpoint.CHi = pollutantIndexRange[category]
+		}
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundDown, int(LEVEL1), nil), nil
+}
+
+// Scale returns the range of categories.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: int(LEVEL1), Max: int(LEVEL5), Categorical: true}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
+}
+
+// AQIToLevel returns the level of category aqi, which is the category itself.
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= int(LEVEL1) {
+		return LEVEL1
+	}
+	if aqi >= int(LEVEL5) {
+		return LEVEL5
+	}
+	return AQILevel(aqi)
+}
+
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
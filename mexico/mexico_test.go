@@ -0,0 +1,160 @@
+package mexico_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/mexico"
+)
+
+var (
+	_ goaqi.StandardWithColor  = &mexico.Algo{}
+	_ goaqi.StandardWithDetail = &mexico.Algo{}
+	_ goaqi.StandardWithScale  = &mexico.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	algo := &mexico.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.O3_1H,
+			Value: 0.06,
+		},
+		{
+			P:     goaqi.PM2_5_12H,
+			Value: 30,
+		},
+	}
+	category, primaryPollutants, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(category)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("category=%v as %v with primary pollutant as %v\n", category, desc, primaryPollutants)
+	// Output: category=2 as Aceptable with primary pollutant as [O3_1H PM2_5_12H]
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr bool
+	}{
+		{
+			name: "upper bound inclusive",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_12H, Value: 50},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "above upper bound",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_12H, Value: 51},
+					{P: goaqi.NO2_1H, Value: 0.05},
+				},
+			},
+			want:  2,
+			want1: []goaqi.Pollutant{goaqi.PM10_12H},
+		},
+		{
+			name: "extremadamente mala",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_12H, Value: 200},
+					{P: goaqi.CO_8H, Value: 12},
+				},
+			},
+			want:  5,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_12H},
+		},
+		{
+			name: "converted from μg/m3",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 200, Unit: goaqi.UNIT_MIU_G_PER_M3},
+				},
+			},
+			want:  4,
+			want1: []goaqi.Pollutant{goaqi.O3_8H},
+		},
+		{
+			name: "unsupported pollutant",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 200},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "NaN value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_12H, Value: math.NaN()},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_12H, Value: -1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &mexico.Algo{}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mexico.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("mexico.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("mexico.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.PM2_5_12H, Value: 10},
+		unsupported,
+	}
+
+	if _, err := (&mexico.Algo{}).CalcWithDetail(inputs...); err != nil {
+		t.Fatalf("mexico.CalcWithDetail() error = %v", err)
+	}
+	_, err := (&mexico.Algo{Strict: true}).CalcWithDetail(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("mexico.CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
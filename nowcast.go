@@ -0,0 +1,45 @@
+package goaqi
+
+import "math"
+
+// NowCast returns the NowCast weighted average of hourly values, most recent
+// first, as HourlyValues returns. NaN values are treated as missing.
+//
+// The weight factor is the ratio of the minimum to the maximum value, but no
+// less than minWeight. At least 2 of the 3 most recent hours must be valid.
+//
+// https://usepa.servicenowservices.com/airnow?id=kb_article_view&sysparm_article=KB0011856
+func NowCast(values []float64, minWeight float64) (float64, error) {
+	var recent int
+	for i := 0; i < 3 && i < len(values); i++ {
+		if !math.IsNaN(values[i]) {
+			recent++
+		}
+	}
+	if recent < 2 {
+		return 0, ErrInsufficientData
+	}
+
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		minValue = math.Min(minValue, value)
+		maxValue = math.Max(maxValue, value)
+	}
+	weight := 1.0
+	if maxValue > 0 {
+		weight = math.Max(minValue/maxValue, minWeight)
+	}
+
+	var sum, weights float64
+	for i, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		sum += math.Pow(weight, float64(i)) * value
+		weights += math.Pow(weight, float64(i))
+	}
+	return sum / weights, nil
+}
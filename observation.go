@@ -0,0 +1,63 @@
+package goaqi
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrInsufficientData is returned when there are not enough valid hours to
+// compute an average.
+var ErrInsufficientData = errors.New("go-aqi: insufficient data")
+
+// Observation is an hourly Var.
+type Observation struct {
+	Var
+
+	// Time is the start of the hour the value is averaged over.
+	Time time.Time
+}
+
+// HourlyValues returns values of p converted to unit u for n hours, starting
+// with the hour of end and going back in time. Missing hours are NaN.
+//
+// If there are multiple observations for the same hour, the last one wins.
+func HourlyValues(observations []*Observation, p Pollutant, u Unit, end time.Time, n int) ([]float64, error) {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = math.NaN()
+	}
+	end = end.Truncate(time.Hour)
+	for _, observation := range observations {
+		if observation.P != p {
+			continue
+		}
+		i := int(end.Sub(observation.Time.Truncate(time.Hour)) / time.Hour)
+		if i < 0 || i >= n {
+			continue
+		}
+		value, err := observation.ValueIn(u)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// HourlyObservations is the inverse of HourlyValues, returning observations of
+// p for values starting with the hour of end and going back in time. NaN values
+// are missing hours and left out.
+func HourlyObservations(p Pollutant, end time.Time, values ...float64) []*Observation {
+	observations := make([]*Observation, 0, len(values))
+	for i, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		observations = append(observations, &Observation{
+			Var:  Var{P: p, Value: value},
+			Time: end.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+	return observations
+}
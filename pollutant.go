@@ -7,23 +7,74 @@ const (
 	AQI       Pollutant = 1  // Air Quality Index
 	O3_1H     Pollutant = 10 // Ozone 1 hour
 	O3_8H     Pollutant = 11 // Ozone 8 hour
+	O3_3H     Pollutant = 12 // Ozone 3 hour
 	PM2_5_1H  Pollutant = 20 // PM2.5 1 hour
 	PM2_5_24H Pollutant = 21 // PM2.5 24 hour
+	PM2_5_3H  Pollutant = 22 // PM2.5 3 hour
+	PM2_5_12H Pollutant = 23 // PM2.5 12 hour
 	PM10_1H   Pollutant = 30 // PM10 1 hour
 	PM10_24H  Pollutant = 31 // PM10 24 hour
+	PM10_3H   Pollutant = 32 // PM10 3 hour
+	PM10_12H  Pollutant = 33 // PM10 12 hour
 	SO2_1H    Pollutant = 40 // Sulfur Dioxide 1 hour
 	SO2_24H   Pollutant = 41 // Sulfur Dioxide 24 hour
+	SO2_15M   Pollutant = 42 // Sulfur Dioxide 15 minute
+	SO2_3H    Pollutant = 43 // Sulfur Dioxide 3 hour
 	NO2_1H    Pollutant = 50 // Nitrogen Dioxide 1 hour
 	NO2_24H   Pollutant = 51 // Nitrogen Dioxide 24 hour
+	NO2_3H    Pollutant = 52 // Nitrogen Dioxide 3 hour
 	CO_1H     Pollutant = 60 // Carbon Monoxide 1 hour
 	CO_8H     Pollutant = 61 // Carbon Monoxide 8 hour
 	CO_24H    Pollutant = 62 // Carbon Monoxide 24 hour
+	NH3_24H   Pollutant = 70 // Ammonia 24 hour
+	PB_24H    Pollutant = 80 // Lead 24 hour
+	NEPH_1H   Pollutant = 90 // Visibility reduction by nephelometer 1 hour
 )
 
 type AQIStandard int32
 
 const (
-	AQISTANDARD_UNSPECIFIED AQIStandard = 0 // Unspecified
-	AQISTANDARD_US          AQIStandard = 1 // US AQI
-	AQISTANDARD_CN          AQIStandard = 2 // China AQI
+	AQISTANDARD_UNSPECIFIED AQIStandard = 0  // Unspecified
+	AQISTANDARD_US          AQIStandard = 1  // US AQI
+	AQISTANDARD_CN          AQIStandard = 2  // China AQI
+	AQISTANDARD_IN          AQIStandard = 3  // India National AQI
+	AQISTANDARD_EU          AQIStandard = 4  // European AQI
+	AQISTANDARD_UK          AQIStandard = 5  // UK Daily AQI
+	AQISTANDARD_CA          AQIStandard = 6  // Canada AQHI
+	AQISTANDARD_HK          AQIStandard = 7  // Hong Kong AQHI
+	AQISTANDARD_SG          AQIStandard = 8  // Singapore PSI
+	AQISTANDARD_KR          AQIStandard = 9  // Korea CAI
+	AQISTANDARD_MX          AQIStandard = 10 // Mexico Índice AIRE y SALUD
+	AQISTANDARD_AU          AQIStandard = 11 // Australia Air Quality Category
+	AQISTANDARD_TW          AQIStandard = 12 // Taiwan AQI
+	AQISTANDARD_CAQI        AQIStandard = 13 // Common Air Quality Index of European cities
+)
+
+type Unit int32
+
+const (
+	UNIT_UNSPECIFIED  Unit = 0 // Unspecified, value is already in the unit expected by standard
+	UNIT_MIU_G_PER_M3 Unit = 1 // μg/m3
+	UNIT_MG_PER_M3    Unit = 2 // mg/m3
+	UNIT_PPM          Unit = 3 // ppm
+	UNIT_PPB          Unit = 4 // ppb
+)
+
+type SkipReason int32
+
+const (
+	SKIPREASON_NONE           SkipReason = 0 // Not skipped
+	SKIPREASON_UNSUPPORTED    SkipReason = 1 // Pollutant not supported by the standard
+	SKIPREASON_OUT_OF_DOMAIN  SkipReason = 2 // Value beyond the range the standard defines for the pollutant
+	SKIPREASON_NOT_APPLICABLE SkipReason = 3 // Value where the standard uses another pollutant instead
+)
+
+type WarningKind int32
+
+const (
+	WARNINGKIND_UNSPECIFIED        WarningKind = 0 // Unspecified
+	WARNINGKIND_SKIPPED            WarningKind = 1 // Skipped without substitute
+	WARNINGKIND_SUBSTITUTED        WarningKind = 2 // Skipped and substituted by another averaging period
+	WARNINGKIND_MISSING_SUBSTITUTE WarningKind = 3 // Skipped but the required substitute is not given
+	WARNINGKIND_CAPPED             WarningKind = 4 // Sub-index capped at the highest index
 )
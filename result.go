@@ -0,0 +1,174 @@
+package goaqi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Breakpoint is a segment of breakpoints table, mapping concentration from
+// [CLo, CHi] to index from [ILo, IHi].
+type Breakpoint struct {
+	CLo float64
+	CHi float64
+	ILo float64
+	IHi float64
+}
+
+// CalcIAQI returns the unrounded sub-index of value by piecewise-linear
+// interpolation over pIndexRange and aqiIndexRange, which is shared by most
+// standards. It is Table.Calc of NewTable(pIndexRange, aqiIndexRange), each
+// standard rounds the result by its own Rounding.
+func CalcIAQI(value float64, pIndexRange []float64, aqiIndexRange []float64) (float64, *Breakpoint, error) {
+	return NewTable(pIndexRange, aqiIndexRange).Calc(value)
+}
+
+// IAQI is the individual AQI, or sub-index, of a pollutant.
+type IAQI struct {
+	Var   *Var
+	Value int
+
+	// RawValue is Value before rounding.
+	RawValue float64
+
+	// Breakpoint used to compute Value, nil if Value is capped because the
+	// concentration is above the highest breakpoint.
+	Breakpoint *Breakpoint
+
+	// Skipped is true if Var takes no part in AQI, like unsupported
+	// pollutants or values out of the pollutant's domain.
+	Skipped bool
+
+	// SkipReason tells why Var is skipped.
+	SkipReason SkipReason
+}
+
+// Result is the AQI with sub-index of every pollutant.
+type Result struct {
+	AQI               int
+	PrimaryPollutants []Pollutant
+
+	// RawAQI is AQI before rounding.
+	RawAQI float64
+
+	// IAQIs in the same order as input vars.
+	IAQIs []*IAQI
+
+	// Warnings of skipped, substituted or capped vars, see Diagnose.
+	Warnings []*Warning
+}
+
+// Aggregator combines unrounded sub-indices into the unrounded overall index
+// of a standard, see NewResult. Skipped ones should be ignored. Most standards
+// use MaxRawValue.
+type Aggregator func(iaqis []*IAQI) float64
+
+// NewResult returns the Result of iaqis, with RawAQI combined by aggregate and
+// AQI rounded from it by round, the rounding of sub-indices. Primary pollutants
+// are reported only if AQI is above goodAQI, the highest AQI of the lowest
+// level. Warnings are diagnosed with substitutes, see Diagnose.
+func NewResult(iaqis []*IAQI, aggregate Aggregator, round Rounding, goodAQI int, substitutes map[Pollutant]Pollutant) *Result {
+	rawAQI := aggregate(iaqis)
+	result := &Result{AQI: round(rawAQI), RawAQI: rawAQI, IAQIs: iaqis, Warnings: Diagnose(iaqis, substitutes)}
+	if result.AQI > goodAQI {
+		result.PrimaryPollutants = PrimaryPollutants(iaqis)
+	}
+	return result
+}
+
+// MaxRawValue returns the highest RawValue of iaqis, or 0 if all of them are
+// skipped. It is the Aggregator of most standards.
+func MaxRawValue(iaqis []*IAQI) float64 {
+	var maxValue float64
+	for _, iaqi := range iaqis {
+		if !iaqi.Skipped && iaqi.RawValue > maxValue {
+			maxValue = iaqi.RawValue
+		}
+	}
+	return maxValue
+}
+
+// PrimaryPollutants returns pollutants whose sub-index equals the highest
+// sub-index of iaqis. Skipped ones are ignored.
+//
+// Pollutants are ordered by their first appearance in iaqis, which is the
+// input order of Calc, so the result is stable for ties.
+func PrimaryPollutants(iaqis []*IAQI) []Pollutant {
+	var (
+		maxAQI int
+		found  bool
+	)
+	for _, iaqi := range iaqis {
+		if iaqi.Skipped {
+			continue
+		}
+		if !found || iaqi.Value > maxAQI {
+			maxAQI, found = iaqi.Value, true
+		}
+	}
+
+	primaryPollutants := make([]Pollutant, 0)
+	seen := make(map[Pollutant]bool)
+	for _, iaqi := range iaqis {
+		if iaqi.Skipped || iaqi.Value != maxAQI || seen[iaqi.Var.P] {
+			continue
+		}
+		seen[iaqi.Var.P] = true
+		primaryPollutants = append(primaryPollutants, iaqi.Var.P)
+	}
+	return primaryPollutants
+}
+
+// SkippedError is returned by algorithms in strict mode for pollutants which
+// would be skipped silently otherwise.
+type SkippedError struct {
+	// IAQIs are the skipped ones, with the offending Var and SkipReason.
+	IAQIs []*IAQI
+}
+
+func (e *SkippedError) Error() string {
+	vars := make([]string, 0, len(e.IAQIs))
+	for _, iaqi := range e.IAQIs {
+		vars = append(vars, fmt.Sprintf("%v=%v (%v)", iaqi.Var.P, iaqi.Var.Value, iaqi.SkipReason))
+	}
+	return "go-aqi: skipped pollutants: " + strings.Join(vars, ", ")
+}
+
+// Vars returns the offending Vars of e.
+func (e *SkippedError) Vars() []*Var {
+	vars := make([]*Var, 0, len(e.IAQIs))
+	for _, iaqi := range e.IAQIs {
+		vars = append(vars, iaqi.Var)
+	}
+	return vars
+}
+
+// CheckSkipped returns *SkippedError for iaqis skipped as unsupported or out
+// of domain, or nil if there is none. Those skipped as not applicable are
+// expected by the standard and are not errors.
+func CheckSkipped(iaqis []*IAQI) error {
+	var skipped []*IAQI
+	for _, iaqi := range iaqis {
+		if iaqi.SkipReason == SKIPREASON_UNSUPPORTED || iaqi.SkipReason == SKIPREASON_OUT_OF_DOMAIN {
+			skipped = append(skipped, iaqi)
+		}
+	}
+	if len(skipped) == 0 {
+		return nil
+	}
+	return &SkippedError{IAQIs: skipped}
+}
+
+type StandardWithDetail interface {
+	Standard
+
+	// CalcWithDetail is like Calc, but also returns sub-index of every pollutant.
+	CalcWithDetail(pollutantVars ...*Var) (*Result, error)
+}
+
+type StandardWithInverse interface {
+	Standard
+
+	// AQIToConcentration returns the lowest concentration of p, in the unit
+	// from ExpectedUnit, whose sub-index is aqi.
+	AQIToConcentration(p Pollutant, aqi int) (float64, error)
+}
@@ -0,0 +1,38 @@
+package goaqi
+
+import "math"
+
+// roundingEpsilon absorbs floating point error of interpolation, so that an
+// index like 99.99999999999999 is treated as 100.
+const roundingEpsilon = 1e-9
+
+// Rounding turns an unrounded index into the integer a standard reports.
+type Rounding func(value float64) int
+
+// RoundDown drops the fraction of value.
+func RoundDown(value float64) int {
+	return int(math.Floor(value + roundingEpsilon))
+}
+
+// RoundHalfUp rounds value to the nearest integer, like EPA requires.
+func RoundHalfUp(value float64) int {
+	return int(math.Floor(value + 0.5 + roundingEpsilon))
+}
+
+// RoundUp rounds value up to the next integer, like 进位取整 of HJ 633.
+func RoundUp(value float64) int {
+	return int(math.Ceil(value - roundingEpsilon))
+}
+
+var (
+	_ Rounding = RoundDown
+	_ Rounding = RoundHalfUp
+	_ Rounding = RoundUp
+)
+
+// Truncate drops digits of value after decimals decimal places, like EPA
+// requires for concentrations before looking up breakpoints.
+func Truncate(value float64, decimals int) float64 {
+	p := math.Pow10(decimals)
+	return math.Floor(value*p+roundingEpsilon) / p
+}
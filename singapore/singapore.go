@@ -0,0 +1,278 @@
+// Package singapore is for Pollutant Standards Index of Singapore
+//
+// PSI is published by National Environment Agency (NEA) with sub-index of six
+// pollutants. NEA also publishes 1-hour PM2.5 concentration bands alongside it.
+//
+// Offical Doc
+// https://www.haze.gov.sg/
+package singapore
+
+import (
+	"fmt"
+	"image/color"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_SG
+
+// tables are breakpoints of NEA, which are contiguous as NEA defines PSI at
+// breakpoint concentrations rather than ranges. NO2 defines PSI above 200 only.
+var tables = map[goaqi.Pollutant]goaqi.Table{
+	goaqi.PM2_5_24H: { // μg/m3
+		{CLo: 0, CHi: 12, ILo: 0, IHi: 50},
+		{CLo: 12, CHi: 55, ILo: 50, IHi: 100},
+		{CLo: 55, CHi: 150, ILo: 100, IHi: 200},
+		{CLo: 150, CHi: 250, ILo: 200, IHi: 300},
+		{CLo: 250, CHi: 350, ILo: 300, IHi: 400},
+		{CLo: 350, CHi: 500, ILo: 400, IHi: 500},
+	},
+	goaqi.PM10_24H: { // μg/m3
+		{CLo: 0, CHi: 50, ILo: 0, IHi: 50},
+		{CLo: 50, CHi: 150, ILo: 50, IHi: 100},
+		{CLo: 150, CHi: 350, ILo: 100, IHi: 200},
+		{CLo: 350, CHi: 420, ILo: 200, IHi: 300},
+		{CLo: 420, CHi: 500, ILo: 300, IHi: 400},
+		{CLo: 500, CHi: 600, ILo: 400, IHi: 500},
+	},
+	goaqi.SO2_24H: { // μg/m3
+		{CLo: 0, CHi: 80, ILo: 0, IHi: 50},
+		{CLo: 80, CHi: 365, ILo: 50, IHi: 100},
+		{CLo: 365, CHi: 800, ILo: 100, IHi: 200},
+		{CLo: 800, CHi: 1600, ILo: 200, IHi: 300},
+		{CLo: 1600, CHi: 2100, ILo: 300, IHi: 400},
+		{CLo: 2100, CHi: 2620, ILo: 400, IHi: 500},
+	},
+	goaqi.CO_8H: { // mg/m3
+		{CLo: 0, CHi: 5, ILo: 0, IHi: 50},
+		{CLo: 5, CHi: 10, ILo: 50, IHi: 100},
+		{CLo: 10, CHi: 17, ILo: 100, IHi: 200},
+		{CLo: 17, CHi: 34, ILo: 200, IHi: 300},
+		{CLo: 34, CHi: 46, ILo: 300, IHi: 400},
+		{CLo: 46, CHi: 57.5, ILo: 400, IHi: 500},
+	},
+	goaqi.O3_8H: { // μg/m3
+		{CLo: 0, CHi: 118, ILo: 0, IHi: 50},
+		{CLo: 118, CHi: 157, ILo: 50, IHi: 100},
+		{CLo: 157, CHi: 235, ILo: 100, IHi: 200},
+		{CLo: 235, CHi: 785, ILo: 200, IHi: 300},
+		{CLo: 785, CHi: 980, ILo: 300, IHi: 400},
+		{CLo: 980, CHi: 1180, ILo: 400, IHi: 500},
+	},
+	goaqi.NO2_1H: { // μg/m3
+		{CLo: 1130, CHi: 2260, ILo: 200, IHi: 300},
+		{CLo: 2260, CHi: 3000, ILo: 300, IHi: 400},
+		{CLo: 3000, CHi: 3750, ILo: 400, IHi: 500},
+	},
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.PM2_5_24H: goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM10_24H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.SO2_24H:   goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.CO_8H:     goaqi.UNIT_MG_PER_M3,
+	goaqi.O3_8H:     goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.NO2_1H:    goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM2_5_1H:  goaqi.UNIT_MIU_G_PER_M3,
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+	LEVEL5
+)
+
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 0, G: 176, B: 80},
+	LEVEL2: {R: 0, G: 112, B: 192},
+	LEVEL3: {R: 255, G: 192, B: 0},
+	LEVEL4: {R: 255, G: 128, B: 0},
+	LEVEL5: {R: 255, G: 0, B: 0},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Good",
+	LEVEL2: "Moderate",
+	LEVEL3: "Unhealthy",
+	LEVEL4: "Very unhealthy",
+	LEVEL5: "Hazardous",
+}
+
+// PM25Band is the band of 1-hour PM2.5 concentration.
+type PM25Band int
+
+const (
+	PM25_BAND_UNDEFINE PM25Band = iota
+	PM25_BAND_I
+	PM25_BAND_II
+	PM25_BAND_III
+	PM25_BAND_IV
+)
+
+// pm25Bands are upper bounds of band I to III in μg/m3, values above the last
+// one are band IV.
+var pm25Bands = []float64{55, 150, 250}
+
+var pm25BandToDesc = map[PM25Band]string{
+	PM25_BAND_I:   "Normal",
+	PM25_BAND_II:  "Elevated",
+	PM25_BAND_III: "High",
+	PM25_BAND_IV:  "Very High",
+}
+
+// Report is the 24-hour PSI with the 1-hour PM2.5 band published alongside it.
+type Report struct {
+	PSI *goaqi.Result
+
+	// PM25Band is PM25_BAND_UNDEFINE if PM2_5_1H is not given.
+	PM25Band PM25Band
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "singapore"
+}
+
+// Calc is func for 24-hour PSI computing. NO2 only takes part when its 1-hour
+// concentration reaches PSI 200.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns sub-index of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		table, ok := tables[pollutantVar.P]
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+
+		// NO2 sub-index is not reported below PSI 200.
+		if pollutantVar.P == goaqi.NO2_1H && 0 <= value && value < table[0].CLo {
+			iaqi.SkipReason = goaqi.SKIPREASON_NOT_APPLICABLE
+			continue
+		}
+
+		rawAQI, breakpoint, err := table.Calc(value)
+		if err != nil {
+			return nil, err
+		}
+		iaqi.Value, iaqi.RawValue, iaqi.Breakpoint, iaqi.Skipped = goaqi.RoundDown(rawAQI), rawAQI, breakpoint, false
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundDown, 50, nil), nil
+}
+
+// CalcReport computes the 24-hour PSI and the band of PM2_5_1H if given.
+func (a *Algo) CalcReport(pollutantVars ...*goaqi.Var) (*Report, error) {
+	// PM2_5_1H is for the band only, keep it out of PSI.
+	psiVars := make([]*goaqi.Var, 0, len(pollutantVars))
+	var pm25Band PM25Band
+	for _, pollutantVar := range pollutantVars {
+		if pollutantVar.P != goaqi.PM2_5_1H {
+			psiVars = append(psiVars, pollutantVar)
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+		if err := goaqi.ValidateValue(value); err != nil {
+			return nil, err
+		}
+		pm25Band = a.PM25ToBand(value)
+	}
+	result, err := a.CalcWithDetail(psiVars...)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{PSI: result, PM25Band: pm25Band}, nil
+}
+
+// Scale returns the range of PSI.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 0, Max: 500}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
+}
+
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= 50 {
+		return LEVEL1
+	}
+	if aqi <= 100 {
+		return LEVEL2
+	}
+	if aqi <= 200 {
+		return LEVEL3
+	}
+	if aqi <= 300 {
+		return LEVEL4
+	}
+	return LEVEL5
+}
+
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
+
+// PM25ToBand returns the band of 1-hour PM2.5 concentration in μg/m3.
+func (a *Algo) PM25ToBand(value float64) PM25Band {
+	for i, band := range pm25Bands {
+		if value <= band {
+			return PM25Band(i + 1)
+		}
+	}
+	return PM25_BAND_IV
+}
+
+func (a *Algo) PM25BandToDesc(band PM25Band) (string, error) {
+	desc, ok := pm25BandToDesc[band]
+	if !ok {
+		return "", fmt.Errorf("unknown pm2.5 band for desc")
+	}
+	return desc, nil
+}
@@ -0,0 +1,195 @@
+package singapore_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/singapore"
+)
+
+var (
+	_ goaqi.StandardWithColor  = &singapore.Algo{}
+	_ goaqi.StandardWithDetail = &singapore.Algo{}
+	_ goaqi.StandardWithScale  = &singapore.Algo{}
+)
+
+func ExampleAlgo_CalcReport() {
+	algo := &singapore.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.PM2_5_24H,
+			Value: 40,
+		},
+		{
+			P:     goaqi.PM10_24H,
+			Value: 60,
+		},
+		{
+			P:     goaqi.O3_8H,
+			Value: 50,
+		},
+		{
+			P:     goaqi.PM2_5_1H,
+			Value: 70,
+		},
+	}
+	report, err := algo.CalcReport(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(report.PSI.AQI)
+	if err != nil {
+		panic(err)
+	}
+	band, err := algo.PM25BandToDesc(report.PM25Band)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("psi=%v as %v with primary pollutant as %v, 1-hour pm2.5 is %v\n", report.PSI.AQI, desc, report.PSI.PrimaryPollutants, band)
+	// Output: psi=82 as Moderate with primary pollutant as [PM2_5_24H], 1-hour pm2.5 is Elevated
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr bool
+	}{
+		{
+			name: "no2 below psi 200 is not reported",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 100},
+					{P: goaqi.NO2_1H, Value: 1000},
+				},
+			},
+			want:  75,
+			want1: []goaqi.Pollutant{goaqi.PM10_24H},
+		},
+		{
+			name: "no2 above psi 200",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 100},
+					{P: goaqi.NO2_1H, Value: 1695},
+				},
+			},
+			want:  250,
+			want1: []goaqi.Pollutant{goaqi.NO2_1H},
+		},
+		{
+			name: "zero",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.CO_8H, Value: 0},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "capped",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.SO2_24H, Value: 3000},
+				},
+			},
+			want:  500,
+			want1: []goaqi.Pollutant{goaqi.SO2_24H},
+		},
+		{
+			name: "negative no2",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 100},
+					{P: goaqi.NO2_1H, Value: -1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &singapore.Algo{}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("singapore.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("singapore.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("singapore.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestAlgo_PM25ToBand(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  singapore.PM25Band
+	}{
+		{value: 0, want: singapore.PM25_BAND_I},
+		{value: 55, want: singapore.PM25_BAND_I},
+		{value: 56, want: singapore.PM25_BAND_II},
+		{value: 250, want: singapore.PM25_BAND_III},
+		{value: 251, want: singapore.PM25_BAND_IV},
+	}
+	for _, tt := range tests {
+		a := &singapore.Algo{}
+		if got := a.PM25ToBand(tt.value); got != tt.want {
+			t.Errorf("singapore.PM25ToBand(%v) got = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAlgo_CalcReport(t *testing.T) {
+	inputs := []*goaqi.Var{
+		{P: goaqi.PM2_5_24H, Value: 40},
+		{P: goaqi.PM2_5_1H, Value: 30},
+	}
+	report, err := (&singapore.Algo{Strict: true}).CalcReport(inputs...)
+	if err != nil {
+		t.Fatalf("CalcReport() error = %v", err)
+	}
+	if report.PSI.Warnings != nil {
+		t.Errorf("CalcReport() PSI.Warnings = %v, want nil", report.PSI.Warnings)
+	}
+	if len(report.PSI.IAQIs) != 1 {
+		t.Errorf("CalcReport() PSI.IAQIs = %v, want PM2_5_24H only", report.PSI.IAQIs)
+	}
+	if report.PM25Band != singapore.PM25_BAND_I {
+		t.Errorf("CalcReport() PM25Band = %v, want %v", report.PM25Band, singapore.PM25_BAND_I)
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.PM2_5_24H, Value: 10},
+		// NO2 below PSI 200 is not applicable rather than unsupported.
+		{P: goaqi.NO2_1H, Value: 100},
+		unsupported,
+	}
+
+	if _, err := (&singapore.Algo{}).CalcWithDetail(inputs...); err != nil {
+		t.Fatalf("singapore.CalcWithDetail() error = %v", err)
+	}
+	_, err := (&singapore.Algo{Strict: true}).CalcWithDetail(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("singapore.CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
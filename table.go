@@ -0,0 +1,121 @@
+package goaqi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrNegativeValue is returned for negative concentrations.
+	ErrNegativeValue = errors.New("go-aqi: negative value")
+
+	// ErrNaNValue is returned for NaN concentrations.
+	ErrNaNValue = errors.New("go-aqi: NaN value")
+
+	// ErrOutOfRange is returned for values not covered by a Table.
+	ErrOutOfRange = errors.New("go-aqi: value out of range")
+)
+
+// Table is a breakpoints table with rows mapping concentration from
+// [CLo, CHi] to index from [ILo, IHi], in ascending order, like the tables of
+// official docs.
+//
+// Rows may have gaps between them, like 12.0 and 12.1 of EPA PM2.5, where a
+// concentration truncated as the standard requires never falls. Values in a
+// gap belong to the lower row.
+type Table []Breakpoint
+
+// NewTable returns a Table of contiguous rows from breakpoints and the
+// matching index row, where row i maps [pIndexRange[i], pIndexRange[i+1]] to
+// [aqiIndexRange[i], aqiIndexRange[i+1]].
+func NewTable(pIndexRange []float64, aqiIndexRange []float64) Table {
+	n := min(len(pIndexRange), len(aqiIndexRange))
+	table := make(Table, 0, n)
+	for i := 0; i+1 < n; i++ {
+		table = append(table, Breakpoint{
+			CLo: pIndexRange[i],
+			CHi: pIndexRange[i+1],
+			ILo: aqiIndexRange[i],
+			IHi: aqiIndexRange[i+1],
+		})
+	}
+	return table
+}
+
+// ValidateValue returns ErrNaNValue and ErrNegativeValue for NaN and negative
+// concentrations. Table does it on lookup, standards computing index without
+// Table, like bands or formulas, should call it for every value.
+func ValidateValue(value float64) error {
+	if math.IsNaN(value) {
+		return ErrNaNValue
+	}
+	if value < 0 {
+		return fmt.Errorf("%w: %v", ErrNegativeValue, value)
+	}
+	return nil
+}
+
+// Lookup returns the row of value.
+//
+// ErrNaNValue and ErrNegativeValue are returned for NaN and negative values,
+// ErrOutOfRange for values below the first row or above the last row.
+func (t Table) Lookup(value float64) (*Breakpoint, error) {
+	if err := ValidateValue(value); err != nil {
+		return nil, err
+	}
+	for i := range t {
+		if value < t[i].CLo {
+			if i == 0 {
+				break
+			}
+			return &t[i-1], nil
+		}
+		if value <= t[i].CHi {
+			return &t[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %v", ErrOutOfRange, value)
+}
+
+// Calc returns the unrounded index of value by linear interpolation in its
+// row. Value above the last row is capped at the highest index, with nil
+// Breakpoint.
+func (t Table) Calc(value float64) (float64, *Breakpoint, error) {
+	if len(t) > 0 && value > t[len(t)-1].CHi {
+		return t[len(t)-1].IHi, nil, nil
+	}
+	breakpoint, err := t.Lookup(value)
+	if err != nil {
+		return 0, nil, err
+	}
+	if value == breakpoint.CLo {
+		return breakpoint.ILo, breakpoint, nil
+	}
+	return CalcRawViaHiLo(value, breakpoint.ILo, breakpoint.IHi, breakpoint.CLo, breakpoint.CHi), breakpoint, nil
+}
+
+// Inverse returns the lowest concentration whose index is aqi. AQI in a gap
+// between rows gets the lowest concentration of the upper row.
+func (t Table) Inverse(aqi float64) (float64, error) {
+	for i, row := range t {
+		if aqi < row.ILo {
+			if i == 0 {
+				break
+			}
+			return row.CLo, nil
+		}
+		if aqi <= row.IHi {
+			return CalcInverseViaHiLo(aqi, row.ILo, row.IHi, row.CLo, row.CHi), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: aqi=%v", ErrOutOfRange, aqi)
+}
+
+// Max returns the highest concentration of t.
+func (t Table) Max() float64 {
+	if len(t) == 0 {
+		return 0
+	}
+	return t[len(t)-1].CHi
+}
@@ -0,0 +1,118 @@
+package goaqi_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+// table is like EPA PM2.5, with a gap between 12.0 and 12.1.
+var table = goaqi.Table{
+	{CLo: 0, CHi: 12.0, ILo: 0, IHi: 50},
+	{CLo: 12.1, CHi: 35.4, ILo: 51, IHi: 100},
+}
+
+func TestTable_Lookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   goaqi.Table
+		value   float64
+		want    *goaqi.Breakpoint
+		wantErr error
+	}{
+		{name: "lowest", table: table, value: 0, want: &table[0]},
+		{name: "upper bound of row", table: table, value: 12.0, want: &table[0]},
+		{name: "in gap", table: table, value: 12.05, want: &table[0]},
+		{name: "lower bound of row", table: table, value: 12.1, want: &table[1]},
+		{name: "highest", table: table, value: 35.4, want: &table[1]},
+		{name: "above last row", table: table, value: 35.5, wantErr: goaqi.ErrOutOfRange},
+		{name: "below first row", table: goaqi.Table{{CLo: 1, CHi: 2, ILo: 1, IHi: 2}}, value: 0.5, wantErr: goaqi.ErrOutOfRange},
+		{name: "empty table", table: goaqi.Table{}, value: 0, wantErr: goaqi.ErrOutOfRange},
+		{name: "NaN value", table: table, value: math.NaN(), wantErr: goaqi.ErrNaNValue},
+		{name: "negative value", table: table, value: -1, wantErr: goaqi.ErrNegativeValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.table.Lookup(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Table.Lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Table.Lookup() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_Calc(t *testing.T) {
+	tests := []struct {
+		name           string
+		table          goaqi.Table
+		value          float64
+		want           float64
+		wantBreakpoint *goaqi.Breakpoint
+		wantErr        error
+	}{
+		{name: "inside row", table: table, value: 6, want: 25, wantBreakpoint: &table[0]},
+		{name: "in gap", table: table, value: 12.05, want: 50 + 0.05*50/12, wantBreakpoint: &table[0]},
+		{name: "lower bound of row", table: table, value: 12.1, want: 51, wantBreakpoint: &table[1]},
+		{name: "capped above last row", table: table, value: 600, want: 100},
+		{name: "empty table", table: goaqi.Table{}, value: 1, wantErr: goaqi.ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, breakpoint, err := tt.table.Calc(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Table.Calc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Table.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(breakpoint, tt.wantBreakpoint) {
+				t.Errorf("Table.Calc() breakpoint = %+v, want %+v", breakpoint, tt.wantBreakpoint)
+			}
+		})
+	}
+}
+
+func TestTable_Inverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   goaqi.Table
+		aqi     float64
+		want    float64
+		wantErr error
+	}{
+		{name: "lowest", table: table, aqi: 0, want: 0},
+		{name: "upper bound of row", table: table, aqi: 50, want: 12.0},
+		{name: "in gap", table: table, aqi: 50.5, want: 12.1},
+		{name: "lower bound of row", table: table, aqi: 51, want: 12.1},
+		{name: "highest", table: table, aqi: 100, want: 35.4},
+		{name: "above last row", table: table, aqi: 101, wantErr: goaqi.ErrOutOfRange},
+		{name: "below first row", table: goaqi.Table{{CLo: 1, CHi: 2, ILo: 101, IHi: 150}}, aqi: 100, wantErr: goaqi.ErrOutOfRange},
+		{name: "empty table", table: goaqi.Table{}, aqi: 0, wantErr: goaqi.ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.table.Inverse(tt.aqi)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Table.Inverse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Table.Inverse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_Max(t *testing.T) {
+	if got := table.Max(); got != 35.4 {
+		t.Errorf("Table.Max() got = %v, want 35.4", got)
+	}
+	if got := (goaqi.Table{}).Max(); got != 0 {
+		t.Errorf("Table.Max() got = %v for empty table, want 0", got)
+	}
+}
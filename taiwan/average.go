@@ -0,0 +1,80 @@
+package taiwan
+
+import (
+	"fmt"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+// Windows are moving averages of MOENV, 8-hour O3 and CO from at least 6
+// hours and 24-hour SO2 from at least 18 hours.
+var Windows = []goaqi.Window{
+	{From: goaqi.O3_1H, To: goaqi.O3_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.CO_1H, To: goaqi.CO_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.SO2_1H, To: goaqi.SO2_24H, Hours: 24, MinValid: 18},
+}
+
+// pmWindows are the 12-hour and 4-hour averages of hourly PM, which are
+// weighted equally by PMAverage.
+var pmWindows = map[goaqi.Pollutant][2]goaqi.Window{
+	goaqi.PM2_5_1H: {
+		{From: goaqi.PM2_5_1H, To: goaqi.PM2_5_24H, Hours: 12, MinValid: 9},
+		{From: goaqi.PM2_5_1H, To: goaqi.PM2_5_24H, Hours: 4, MinValid: 3},
+	},
+	goaqi.PM10_1H: {
+		{From: goaqi.PM10_1H, To: goaqi.PM10_24H, Hours: 12, MinValid: 9},
+		{From: goaqi.PM10_1H, To: goaqi.PM10_24H, Hours: 4, MinValid: 3},
+	},
+}
+
+// PMAverage returns 0.5 × 12-hour average + 0.5 × 4-hour average at the hour
+// of end from hourly observations of p, which should be PM2_5_1H or PM10_1H.
+//
+// The returned Var is PM2_5_24H or PM10_24H in μg/m3, ready to pass to Calc.
+func PMAverage(p goaqi.Pollutant, end time.Time, observations ...*goaqi.Observation) (*goaqi.Var, error) {
+	windows, ok := pmWindows[p]
+	if !ok {
+		return nil, fmt.Errorf("go-aqi: PM average not supported for %v", p)
+	}
+	var value float64
+	for _, w := range windows {
+		average, err := goaqi.MovingAverage(w, end, observations...)
+		if err != nil {
+			return nil, err
+		}
+		averageValue, err := average.ValueIn(units[w.To])
+		if err != nil {
+			return nil, err
+		}
+		value += 0.5 * averageValue
+	}
+	return &goaqi.Var{P: windows[0].To, Value: value, Unit: units[windows[0].To]}, nil
+}
+
+// CalcAt computes AQI at the hour of end from hourly observations. PM uses
+// PMAverage, CO, SO2 and 8-hour O3 use Windows, while 1-hour O3, SO2 and NO2
+// use the observation of that hour as is.
+//
+// Pollutants without enough hours for their average are ignored.
+func (a *Algo) CalcAt(end time.Time, observations ...*goaqi.Observation) (int, []goaqi.Pollutant, error) {
+	pollutantVars, err := goaqi.VarsAt(observations, end, supported, average)
+	if err != nil {
+		return 0, nil, err
+	}
+	return a.Calc(pollutantVars...)
+}
+
+// supported reports whether p is computed by Calc.
+func supported(p goaqi.Pollutant) bool {
+	_, ok := tables[p]
+	return ok
+}
+
+// average is the goaqi.AverageFunc of Taiwan.
+func average(p goaqi.Pollutant, end time.Time, observations ...*goaqi.Observation) (*goaqi.Var, error) {
+	if _, ok := pmWindows[p]; ok {
+		return PMAverage(p, end, observations...)
+	}
+	return goaqi.WindowAverage(Windows, p, end, observations...)
+}
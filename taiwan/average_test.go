@@ -0,0 +1,71 @@
+package taiwan_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/taiwan"
+)
+
+func ExampleAlgo_CalcAt() {
+	algo := &taiwan.Algo{}
+	end := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+	observations := goaqi.HourlyObservations(goaqi.PM2_5_1H, end, 40, 40, 40, 40, 20, 20, 20, 20, 20, 20, 20, 20)
+	observations = append(observations, goaqi.HourlyObservations(goaqi.NO2_1H, end, 20)...)
+
+	aqi, primaryPollutants, err := algo.CalcAt(end, observations...)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("aqi=%v with primary pollutant as %v\n", aqi, primaryPollutants)
+	// Output: aqi=94 with primary pollutant as [PM2_5_24H]
+}
+
+func TestPMAverage(t *testing.T) {
+	end := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+	nan := math.NaN()
+	tests := []struct {
+		name         string
+		p            goaqi.Pollutant
+		observations []*goaqi.Observation
+		want         *goaqi.Var
+		wantErr      error
+	}{
+		{
+			name:         "stable",
+			p:            goaqi.PM10_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM10_1H, end, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50),
+			want:         &goaqi.Var{P: goaqi.PM10_24H, Value: 50, Unit: goaqi.UNIT_MIU_G_PER_M3},
+		},
+		{
+			name:         "rising",
+			p:            goaqi.PM2_5_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM2_5_1H, end, 40, 40, 40, 40, 20, 20, 20, 20, 20, 20, 20, 20),
+			want:         &goaqi.Var{P: goaqi.PM2_5_24H, Value: 0.5*80/3 + 0.5*40, Unit: goaqi.UNIT_MIU_G_PER_M3},
+		},
+		{
+			name:         "insufficient recent hours",
+			p:            goaqi.PM2_5_1H,
+			observations: goaqi.HourlyObservations(goaqi.PM2_5_1H, end, 40, nan, nan, 40, 20, 20, 20, 20, 20, 20, 20, 20),
+			wantErr:      goaqi.ErrInsufficientData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := taiwan.PMAverage(tt.p, end, tt.observations...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("taiwan.PMAverage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.P != tt.want.P || got.Unit != tt.want.Unit || math.Abs(got.Value-tt.want.Value) > 1e-9 {
+				t.Errorf("taiwan.PMAverage() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Package taiwan is for AQI of Taiwan
+//
+// The AQI is published by Ministry of Environment (MOENV). It is like EPA AQI
+// but with its own breakpoints. O3 uses the 8-hour value and also the 1-hour
+// value when it reaches 0.125 ppm, reporting the higher one. PM uses the mean
+// of 12-hour and 4-hour averages, see PMAverage.
+//
+// Offical Doc
+// https://airtw.moenv.gov.tw/CHT/Information/Standard/AirQualityIndicator.aspx
+package taiwan
+
+import (
+	"fmt"
+	"image/color"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_TW
+
+// tables are the MOENV breakpoints, with gaps between rows like 15.4 and 15.5
+// of PM2.5. 1-hour O3 and 24-hour SO2 define higher AQI values only.
+var tables = map[goaqi.Pollutant]goaqi.Table{
+	goaqi.O3_8H: { // ppm
+		{CLo: 0.000, CHi: 0.054, ILo: 0, IHi: 50},
+		{CLo: 0.055, CHi: 0.070, ILo: 51, IHi: 100},
+		{CLo: 0.071, CHi: 0.085, ILo: 101, IHi: 150},
+		{CLo: 0.086, CHi: 0.105, ILo: 151, IHi: 200},
+		{CLo: 0.106, CHi: 0.200, ILo: 201, IHi: 300},
+	},
+	goaqi.O3_1H: { // ppm
+		{CLo: 0.125, CHi: 0.164, ILo: 101, IHi: 150},
+		{CLo: 0.165, CHi: 0.204, ILo: 151, IHi: 200},
+		{CLo: 0.205, CHi: 0.404, ILo: 201, IHi: 300},
+		{CLo: 0.405, CHi: 0.504, ILo: 301, IHi: 400},
+		{CLo: 0.505, CHi: 0.604, ILo: 401, IHi: 500},
+	},
+	goaqi.PM2_5_24H: { // μg/m3
+		{CLo: 0.0, CHi: 15.4, ILo: 0, IHi: 50},
+		{CLo: 15.5, CHi: 35.4, ILo: 51, IHi: 100},
+		{CLo: 35.5, CHi: 54.4, ILo: 101, IHi: 150},
+		{CLo: 54.5, CHi: 150.4, ILo: 151, IHi: 200},
+		{CLo: 150.5, CHi: 250.4, ILo: 201, IHi: 300},
+		{CLo: 250.5, CHi: 350.4, ILo: 301, IHi: 400},
+		{CLo: 350.5, CHi: 500.4, ILo: 401, IHi: 500},
+	},
+	goaqi.PM10_24H: { // μg/m3
+		{CLo: 0, CHi: 54, ILo: 0, IHi: 50},
+		{CLo: 55, CHi: 125, ILo: 51, IHi: 100},
+		{CLo: 126, CHi: 254, ILo: 101, IHi: 150},
+		{CLo: 255, CHi: 354, ILo: 151, IHi: 200},
+		{CLo: 355, CHi: 424, ILo: 201, IHi: 300},
+		{CLo: 425, CHi: 504, ILo: 301, IHi: 400},
+		{CLo: 505, CHi: 604, ILo: 401, IHi: 500},
+	},
+	goaqi.CO_8H: { // ppm
+		{CLo: 0.0, CHi: 4.4, ILo: 0, IHi: 50},
+		{CLo: 4.5, CHi: 9.4, ILo: 51, IHi: 100},
+		{CLo: 9.5, CHi: 12.4, ILo: 101, IHi: 150},
+		{CLo: 12.5, CHi: 15.4, ILo: 151, IHi: 200},
+		{CLo: 15.5, CHi: 30.4, ILo: 201, IHi: 300},
+		{CLo: 30.5, CHi: 40.4, ILo: 301, IHi: 400},
+		{CLo: 40.5, CHi: 50.4, ILo: 401, IHi: 500},
+	},
+	goaqi.SO2_1H: { // ppb
+		{CLo: 0, CHi: 20, ILo: 0, IHi: 50},
+		{CLo: 21, CHi: 75, ILo: 51, IHi: 100},
+		{CLo: 76, CHi: 185, ILo: 101, IHi: 150},
+		{CLo: 186, CHi: 304, ILo: 151, IHi: 200},
+	},
+	goaqi.SO2_24H: { // ppb
+		{CLo: 305, CHi: 604, ILo: 201, IHi: 300},
+		{CLo: 605, CHi: 804, ILo: 301, IHi: 400},
+		{CLo: 805, CHi: 1004, ILo: 401, IHi: 500},
+	},
+	goaqi.NO2_1H: { // ppb
+		{CLo: 0, CHi: 30, ILo: 0, IHi: 50},
+		{CLo: 31, CHi: 100, ILo: 51, IHi: 100},
+		{CLo: 101, CHi: 360, ILo: 101, IHi: 150},
+		{CLo: 361, CHi: 649, ILo: 151, IHi: 200},
+		{CLo: 650, CHi: 1249, ILo: 201, IHi: 300},
+		{CLo: 1250, CHi: 1649, ILo: 301, IHi: 400},
+		{CLo: 1650, CHi: 2049, ILo: 401, IHi: 500},
+	},
+}
+
+// substitutes are pollutants used instead of those out of their domain or not
+// applicable.
+var substitutes = map[goaqi.Pollutant]goaqi.Pollutant{
+	goaqi.O3_8H:   goaqi.O3_1H,
+	goaqi.O3_1H:   goaqi.O3_8H,
+	goaqi.SO2_1H:  goaqi.SO2_24H,
+	goaqi.SO2_24H: goaqi.SO2_1H,
+}
+
+var units = map[goaqi.Pollutant]goaqi.Unit{
+	goaqi.O3_8H:     goaqi.UNIT_PPM,
+	goaqi.O3_1H:     goaqi.UNIT_PPM,
+	goaqi.PM2_5_24H: goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.PM10_24H:  goaqi.UNIT_MIU_G_PER_M3,
+	goaqi.CO_8H:     goaqi.UNIT_PPM,
+	goaqi.SO2_1H:    goaqi.UNIT_PPB,
+	goaqi.SO2_24H:   goaqi.UNIT_PPB,
+	goaqi.NO2_1H:    goaqi.UNIT_PPB,
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+	LEVEL5
+	LEVEL6
+)
+
+var levelToColor = map[AQILevel]*color.RGBA{
+	LEVEL1: {R: 0, G: 228, B: 0},
+	LEVEL2: {R: 255, G: 255, B: 0},
+	LEVEL3: {R: 255, G: 126, B: 0},
+	LEVEL4: {R: 255, G: 0, B: 0},
+	LEVEL5: {R: 143, G: 63, B: 151},
+	LEVEL6: {R: 126, G: 0, B: 35},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Good",
+	LEVEL2: "Moderate",
+	LEVEL3: "Unhealthy for Sensitive Groups",
+	LEVEL4: "Unhealthy for All Groups",
+	LEVEL5: "Very Unhealthy",
+	LEVEL6: "Hazardous",
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants and values
+	// out of their domain, like 8-hour O3 above 0.200 ppm, instead of skipping
+	// them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "taiwan"
+}
+
+// Calc is func for realtime AQI report computing.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns sub-index of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		table, ok := tables[pollutantVar.P]
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+
+		// 1-hour O3 below 0.125 ppm and 24-hour SO2 below 305 ppb do not
+		// define AQI values, the 8-hour O3 and 1-hour SO2 are used instead.
+		if 0 <= value && value < table[0].CLo {
+			iaqi.SkipReason = goaqi.SKIPREASON_NOT_APPLICABLE
+			continue
+		}
+		// 8-hour O3 above 0.200 ppm uses 1-hour O3 only.
+		if pollutantVar.P == goaqi.O3_8H && value > 0.2 {
+			iaqi.SkipReason = goaqi.SKIPREASON_OUT_OF_DOMAIN
+			continue
+		}
+		// 1-hour SO2 above 304 ppb uses 24-hour SO2 only.
+		if pollutantVar.P == goaqi.SO2_1H && value > 304 {
+			iaqi.SkipReason = goaqi.SKIPREASON_OUT_OF_DOMAIN
+			continue
+		}
+
+		rawAQI, breakpoint, err := table.Calc(value)
+		if err != nil {
+			return nil, err
+		}
+		iaqi.Value, iaqi.RawValue, iaqi.Breakpoint, iaqi.Skipped = goaqi.RoundDown(rawAQI), rawAQI, breakpoint, false
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundDown, 50, substitutes), nil
+}
+
+// Scale returns the range of AQI.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 0, Max: 500}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	return units[p]
+}
+
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= 50 {
+		return LEVEL1
+	}
+	if aqi <= 100 {
+		return LEVEL2
+	}
+	if aqi <= 150 {
+		return LEVEL3
+	}
+	if aqi <= 200 {
+		return LEVEL4
+	}
+	if aqi <= 300 {
+		return LEVEL5
+	}
+	return LEVEL6
+}
+
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := levelToColor[a.AQIToLevel(aqi)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi level for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
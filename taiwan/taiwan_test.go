@@ -0,0 +1,194 @@
+package taiwan_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/taiwan"
+)
+
+var (
+	_ goaqi.StandardWithColor  = &taiwan.Algo{}
+	_ goaqi.StandardWithDetail = &taiwan.Algo{}
+	_ goaqi.StandardWithScale  = &taiwan.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	algo := &taiwan.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.PM2_5_24H,
+			Value: 30,
+		},
+		{
+			P:     goaqi.O3_8H,
+			Value: 0.05,
+		},
+	}
+	aqi, primaryPollutants, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(aqi)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("aqi=%v as %v with primary pollutant as %v\n", aqi, desc, primaryPollutants)
+	// Output: aqi=86 as Moderate with primary pollutant as [PM2_5_24H]
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr bool
+	}{
+		{
+			name: "o3 1h higher than 8h",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 0.08},
+					{P: goaqi.O3_1H, Value: 0.18},
+				},
+			},
+			want:  169,
+			want1: []goaqi.Pollutant{goaqi.O3_1H},
+		},
+		{
+			name: "o3 1h below 0.125 ppm",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 0.08},
+					{P: goaqi.O3_1H, Value: 0.1},
+				},
+			},
+			want:  132,
+			want1: []goaqi.Pollutant{goaqi.O3_8H},
+		},
+		{
+			name: "o3 8h above 0.2 ppm",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_8H, Value: 0.25},
+					{P: goaqi.O3_1H, Value: 0.3},
+				},
+			},
+			want:  248,
+			want1: []goaqi.Pollutant{goaqi.O3_1H},
+		},
+		{
+			name: "so2 24h",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.SO2_1H, Value: 400},
+					{P: goaqi.SO2_24H, Value: 454},
+				},
+			},
+			want:  250,
+			want1: []goaqi.Pollutant{goaqi.SO2_24H},
+		},
+		{
+			name: "so2 24h below 305 ppb",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.SO2_1H, Value: 50},
+					{P: goaqi.SO2_24H, Value: 100},
+				},
+			},
+			want:  77,
+			want1: []goaqi.Pollutant{goaqi.SO2_1H},
+		},
+		{
+			name: "lower bound of pm2.5 segment",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: 15.5},
+				},
+			},
+			want:  51,
+			want1: []goaqi.Pollutant{goaqi.PM2_5_24H},
+		},
+		{
+			name: "lowest o3 1h",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_1H, Value: 0.125},
+				},
+			},
+			want:  101,
+			want1: []goaqi.Pollutant{goaqi.O3_1H},
+		},
+		{
+			name: "lowest so2 24h",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.SO2_24H, Value: 305},
+				},
+			},
+			want:  201,
+			want1: []goaqi.Pollutant{goaqi.SO2_24H},
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.O3_1H, Value: -1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.CO_8H, Value: 0},
+				},
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &taiwan.Algo{}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("taiwan.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("taiwan.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("taiwan.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	o3_8h := &goaqi.Var{P: goaqi.O3_8H, Value: 0.25}
+	o3_1h := &goaqi.Var{P: goaqi.O3_1H, Value: 0.3}
+	co_1h := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+
+	_, err := (&taiwan.Algo{Strict: true}).CalcWithDetail(o3_8h, o3_1h, co_1h)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{o3_8h, co_1h}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+
+	// 1-hour O3 below 0.125 ppm is not applicable rather than out of domain.
+	if _, err := (&taiwan.Algo{Strict: true}).CalcWithDetail(&goaqi.Var{P: goaqi.O3_8H, Value: 0.08}, &goaqi.Var{P: goaqi.O3_1H, Value: 0.1}); err != nil {
+		t.Errorf("CalcWithDetail() error = %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// Package uk is for Daily Air Quality Index of UK
+//
+// The index is published by Department for Environment, Food & Rural Affairs
+// (DEFRA), which reports one of ten bands grouped as Low, Moderate, High and
+// Very High.
+//
+// Pollutants use different averaging period: running 8-hour mean of O3, hourly
+// mean of NO2, 15-minute mean of SO2 and running 24-hour mean of PM.
+//
+// Offical Doc
+// https://uk-air.defra.gov.uk/air-pollution/daqi?view=more-info
+package uk
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+)
+
+const Standard = goaqi.AQISTANDARD_UK
+
+// tables are upper bounds of band 1 to 9, values above the last one are band 10.
+var tables = map[goaqi.Pollutant][]float64{
+	goaqi.O3_8H:     {0, 33, 66, 100, 120, 140, 160, 187, 213, 240},   // μg/m3
+	goaqi.NO2_1H:    {0, 67, 134, 200, 267, 334, 400, 467, 534, 600},  // μg/m3
+	goaqi.SO2_15M:   {0, 88, 177, 266, 354, 443, 532, 710, 887, 1064}, // μg/m3
+	goaqi.PM2_5_24H: {0, 11, 23, 35, 41, 47, 53, 58, 64, 70},          // μg/m3
+	goaqi.PM10_24H:  {0, 16, 33, 50, 58, 66, 75, 83, 91, 100},         // μg/m3
+}
+
+const maxBand = 10
+
+// Windows are running means from hourly data. SO2 needs 15-minute mean, which
+// can not be computed from hourly data.
+var Windows = []goaqi.Window{
+	{From: goaqi.O3_1H, To: goaqi.O3_8H, Hours: 8, MinValid: 6},
+	{From: goaqi.PM10_1H, To: goaqi.PM10_24H, Hours: 24, MinValid: 18},
+	{From: goaqi.PM2_5_1H, To: goaqi.PM2_5_24H, Hours: 24, MinValid: 18},
+}
+
+type AQILevel int
+
+const (
+	LEVEL_UNDEFINE AQILevel = iota
+	LEVEL1
+	LEVEL2
+	LEVEL3
+	LEVEL4
+)
+
+var levelToBandRange = map[AQILevel][2]int{
+	LEVEL1: {1, 3},
+	LEVEL2: {4, 6},
+	LEVEL3: {7, 9},
+	LEVEL4: {10, 10},
+}
+
+var bandToColor = map[int]*color.RGBA{
+	1:  {R: 156, G: 255, B: 156},
+	2:  {R: 49, G: 255, B: 0},
+	3:  {R: 49, G: 207, B: 0},
+	4:  {R: 255, G: 255, B: 0},
+	5:  {R: 255, G: 207, B: 0},
+	6:  {R: 255, G: 154, B: 0},
+	7:  {R: 255, G: 100, B: 100},
+	8:  {R: 255, G: 0, B: 0},
+	9:  {R: 153, G: 0, B: 0},
+	10: {R: 206, G: 48, B: 255},
+}
+
+var levelToDesc = map[AQILevel]string{
+	LEVEL1: "Low",
+	LEVEL2: "Moderate",
+	LEVEL3: "High",
+	LEVEL4: "Very High",
+}
+
+type healthMessage struct {
+	AtRisk  string
+	General string
+}
+
+var levelToHealthMessage = map[AQILevel]healthMessage{
+	LEVEL1: {
+		AtRisk:  "Enjoy your usual outdoor activities.",
+		General: "Enjoy your usual outdoor activities.",
+	},
+	LEVEL2: {
+		AtRisk:  "Adults and children with lung problems, and adults with heart problems, who experience symptoms, should consider reducing strenuous physical activity, particularly outdoors.",
+		General: "Enjoy your usual outdoor activities.",
+	},
+	LEVEL3: {
+		AtRisk:  "Adults and children with lung problems, and adults with heart problems, should reduce strenuous physical exertion, particularly outdoors, and particularly if they experience symptoms. People with asthma may find they need to use their reliever inhaler more often. Older people should also reduce physical exertion.",
+		General: "Anyone experiencing discomfort such as sore eyes, cough or sore throat should consider reducing activity, particularly outdoors.",
+	},
+	LEVEL4: {
+		AtRisk:  "Adults and children with lung problems, adults with heart problems, and older people, should avoid strenuous physical activity. People with asthma may find they need to use their reliever inhaler more often.",
+		General: "Reduce physical exertion, particularly outdoors, especially if you experience symptoms such as cough or sore throat.",
+	},
+}
+
+type Algo struct {
+	// Strict returns *goaqi.SkippedError for unsupported pollutants instead of
+	// skipping them silently.
+	Strict bool
+}
+
+func (a *Algo) Name() string {
+	return "uk"
+}
+
+// Calc is func for DAQI computing, which returns the band from 1 to 10, the
+// highest band of all pollutants.
+func (a *Algo) Calc(pollutantVars ...*goaqi.Var) (int, []goaqi.Pollutant, error) {
+	result, err := a.CalcWithDetail(pollutantVars...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.AQI, result.PrimaryPollutants, nil
+}
+
+// CalcWithDetail is like Calc, but also returns band of every pollutant.
+func (a *Algo) CalcWithDetail(pollutantVars ...*goaqi.Var) (*goaqi.Result, error) {
+	iaqis := make([]*goaqi.IAQI, 0, len(pollutantVars))
+
+	for _, pollutantVar := range pollutantVars {
+		iaqi := &goaqi.IAQI{Var: pollutantVar, Skipped: true}
+		iaqis = append(iaqis, iaqi)
+
+		pollutantIndexRange, ok := tables[pollutantVar.P]
+		if !ok {
+			iaqi.SkipReason = goaqi.SKIPREASON_UNSUPPORTED
+			continue
+		}
+		value, err := pollutantVar.ValueIn(a.ExpectedUnit(pollutantVar.P))
+		if err != nil {
+			return nil, err
+		}
+		if err := goaqi.ValidateValue(value); err != nil {
+			return nil, err
+		}
+
+		pollutantBand := maxBand
+		for i := 1; i < len(pollutantIndexRange); i++ {
+			if value <= pollutantIndexRange[i] {
+				pollutantBand = i
+				break
+			}
+		}
+		iaqi.Value, iaqi.RawValue, iaqi.Skipped = pollutantBand, float64(pollutantBand), false
+		iaqi.Breakpoint = &goaqi.Breakpoint{
+			CLo: pollutantIndexRange[pollutantBand-1],
+			CHi: math.Inf(1),
+			ILo: float64(pollutantBand),
+			IHi: float64(pollutantBand),
+		}
+		if pollutantBand < maxBand {
+			iaqi.Breakpoint.CHi = pollutantIndexRange[pollutantBand]
+		}
+	}
+	if a.Strict {
+		if err := goaqi.CheckSkipped(iaqis); err != nil {
+			return nil, err
+		}
+	}
+	return goaqi.NewResult(iaqis, goaqi.MaxRawValue, goaqi.RoundDown, 1, nil), nil
+}
+
+// Scale returns the range of bands.
+func (a *Algo) Scale() goaqi.Scale {
+	return goaqi.Scale{Min: 1, Max: maxBand, Categorical: true}
+}
+
+// ExpectedUnit returns the unit of breakpoints for p.
+func (a *Algo) ExpectedUnit(p goaqi.Pollutant) goaqi.Unit {
+	if _, ok := tables[p]; !ok {
+		return goaqi.UNIT_UNSPECIFIED
+	}
+	return goaqi.UNIT_MIU_G_PER_M3
+}
+
+func (a *Algo) AQIToLevel(aqi int) AQILevel {
+	if aqi <= 3 {
+		return LEVEL1
+	}
+	if aqi <= 6 {
+		return LEVEL2
+	}
+	if aqi <= 9 {
+		return LEVEL3
+	}
+	return LEVEL4
+}
+
+// AQIToColor returns color of band aqi.
+func (a *Algo) AQIToColor(aqi int) (*color.RGBA, error) {
+	rgba, ok := bandToColor[min(max(aqi, 1), maxBand)]
+	if !ok {
+		return nil, fmt.Errorf("unknown aqi band for color")
+	}
+	return rgba, nil
+}
+
+func (a *Algo) AQIToDesc(aqi int) (string, error) {
+	desc, ok := levelToDesc[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", fmt.Errorf("unknown aqi level for desc")
+	}
+	return desc, nil
+}
+
+// AQIToHealthMessage returns health advice of band aqi for at-risk
+// individuals and for the general population.
+func (a *Algo) AQIToHealthMessage(aqi int) (atRisk string, general string, err error) {
+	message, ok := levelToHealthMessage[a.AQIToLevel(aqi)]
+	if !ok {
+		return "", "", fmt.Errorf("unknown aqi level for health message")
+	}
+	return message.AtRisk, message.General, nil
+}
+
+// AQIToConcentration returns the lowest concentration of p in μg/m3 whose
+// band is aqi. The lower bound is exclusive except for band 1.
+func (a *Algo) AQIToConcentration(p goaqi.Pollutant, aqi int) (float64, error) {
+	pollutantIndexRange, ok := tables[p]
+	if !ok {
+		return 0, fmt.Errorf("go-aqi: unsupported pollutant %v", p)
+	}
+	if aqi < 1 || aqi > maxBand {
+		return 0, fmt.Errorf("go-aqi: bad band %v", aqi)
+	}
+	return pollutantIndexRange[aqi-1], nil
+}
+
+// LevelToConcentration returns the concentration range (lo, hi] of p in μg/m3
+// of level, hi is +Inf for LEVEL4.
+func (a *Algo) LevelToConcentration(p goaqi.Pollutant, level AQILevel) (lo float64, hi float64, err error) {
+	bandRange, ok := levelToBandRange[level]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown aqi level for concentration")
+	}
+	lo, err = a.AQIToConcentration(p, bandRange[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	if bandRange[1] == maxBand {
+		return lo, math.Inf(1), nil
+	}
+	return lo, tables[p][bandRange[1]], nil
+}
@@ -0,0 +1,190 @@
+package uk_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	goaqi "github.com/ringsaturn/go-aqi"
+	"github.com/ringsaturn/go-aqi/uk"
+)
+
+var (
+	_ goaqi.StandardWithColor   = &uk.Algo{}
+	_ goaqi.StandardWithDetail  = &uk.Algo{}
+	_ goaqi.StandardWithInverse = &uk.Algo{}
+	_ goaqi.StandardWithScale   = &uk.Algo{}
+)
+
+func ExampleAlgo_Calc() {
+	algo := &uk.Algo{}
+	inputs := []*goaqi.Var{
+		{
+			P:     goaqi.O3_8H,
+			Value: 110,
+		},
+		{
+			P:     goaqi.PM2_5_24H,
+			Value: 30,
+		},
+		{
+			P:     goaqi.NO2_1H,
+			Value: 80,
+		},
+	}
+	band, primaryPollutant, err := algo.Calc(inputs...)
+	if err != nil {
+		panic(err)
+	}
+	desc, err := algo.AQIToDesc(band)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("band=%v as level=%v with primary pollutant as %v\n", band, desc, primaryPollutant)
+	// Output: band=4 as level=Moderate with primary pollutant as [O3_8H]
+}
+
+func ExampleAlgo_AQIToHealthMessage() {
+	algo := &uk.Algo{}
+	_, general, err := algo.AQIToHealthMessage(8)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(general)
+	// Output: Anyone experiencing discomfort such as sore eyes, cough or sore throat should consider reducing activity, particularly outdoors.
+}
+
+func ExampleAlgo_AQIToColor() {
+	algo := &uk.Algo{}
+	rgba, err := algo.AQIToColor(10)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%v", rgba)
+	// Output: &{206 48 255 0}
+}
+
+func TestAlgo_Calc(t *testing.T) {
+	type args struct {
+		pollutantVars []*goaqi.Var
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		want1   []goaqi.Pollutant
+		wantErr bool
+	}{
+		{
+			name: "low",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 16},
+					{P: goaqi.SO2_15M, Value: 88},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "very high",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM10_24H, Value: 101},
+					{P: goaqi.PM2_5_24H, Value: 71},
+					{P: goaqi.SO2_1H, Value: 2000},
+				},
+			},
+			want:  10,
+			want1: []goaqi.Pollutant{goaqi.PM10_24H, goaqi.PM2_5_24H},
+		},
+		{
+			name: "convert units",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.SO2_15M, Value: 0.3, Unit: goaqi.UNIT_PPM},
+				},
+			},
+			want:  8,
+			want1: []goaqi.Pollutant{goaqi.SO2_15M},
+		},
+		{
+			name: "NaN value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: math.NaN()},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative value",
+			args: args{
+				pollutantVars: []*goaqi.Var{
+					{P: goaqi.PM2_5_24H, Value: -1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &uk.Algo{}
+			got, got1, err := a.Calc(tt.args.pollutantVars...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("uk.Calc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("uk.Calc() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("uk.Calc() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestWindows(t *testing.T) {
+	end := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	averager := goaqi.NewAverager(uk.Windows...)
+	for i := 0; i < 8; i++ {
+		averager.Add(&goaqi.Observation{
+			Var:  goaqi.Var{P: goaqi.O3_1H, Value: float64(100 + i*10)},
+			Time: end.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+	pollutantVars, err := averager.At(end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	band, _, err := (&uk.Algo{}).Calc(pollutantVars...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if band != 5 {
+		t.Errorf("uk.Calc() got = %v, want %v", band, 5)
+	}
+}
+
+func TestAlgo_CalcWithDetail_strict(t *testing.T) {
+	unsupported := &goaqi.Var{P: goaqi.CO_1H, Value: 1}
+	inputs := []*goaqi.Var{
+		{P: goaqi.PM2_5_24H, Value: 10},
+		unsupported,
+	}
+
+	if _, err := (&uk.Algo{}).CalcWithDetail(inputs...); err != nil {
+		t.Fatalf("uk.CalcWithDetail() error = %v", err)
+	}
+	_, err := (&uk.Algo{Strict: true}).CalcWithDetail(inputs...)
+	var skippedErr *goaqi.SkippedError
+	if !errors.As(err, &skippedErr) {
+		t.Fatalf("uk.CalcWithDetail() error = %v, want *goaqi.SkippedError", err)
+	}
+	if got, want := skippedErr.Vars(), []*goaqi.Var{unsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedError.Vars() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package goaqi
+
+import "fmt"
+
+// Warning is a diagnostic of a Var which may make AQI understated or
+// overstated, for QA of input data.
+type Warning struct {
+	Kind WarningKind
+	Var  *Var
+
+	// Substitute is the pollutant used, or required, instead of Var for
+	// WARNINGKIND_SUBSTITUTED and WARNINGKIND_MISSING_SUBSTITUTE.
+	Substitute Pollutant
+}
+
+func (w *Warning) String() string {
+	switch w.Kind {
+	case WARNINGKIND_SUBSTITUTED:
+		return fmt.Sprintf("%v=%v substituted by %v", w.Var.P, w.Var.Value, w.Substitute)
+	case WARNINGKIND_MISSING_SUBSTITUTE:
+		return fmt.Sprintf("%v=%v skipped but %v is missing", w.Var.P, w.Var.Value, w.Substitute)
+	case WARNINGKIND_CAPPED:
+		return fmt.Sprintf("%v=%v capped", w.Var.P, w.Var.Value)
+	default:
+		return fmt.Sprintf("%v=%v skipped", w.Var.P, w.Var.Value)
+	}
+}
+
+// Diagnose returns warnings of iaqis, in the same order.
+//
+// substitutes maps a pollutant to the one a standard uses instead when it is
+// out of domain or not applicable, like 1-hour O3 for 8-hour O3 above 0.200
+// ppm of EPA. A skipped Var is substituted only if its substitute is not
+// skipped. Vars not applicable without substitute are expected by the standard
+// and get no warning.
+func Diagnose(iaqis []*IAQI, substitutes map[Pollutant]Pollutant) []*Warning {
+	given := make(map[Pollutant]bool)
+	for _, iaqi := range iaqis {
+		if !iaqi.Skipped {
+			given[iaqi.Var.P] = true
+		}
+	}
+
+	var warnings []*Warning
+	for _, iaqi := range iaqis {
+		if !iaqi.Skipped {
+			if iaqi.Breakpoint == nil {
+				warnings = append(warnings, &Warning{Kind: WARNINGKIND_CAPPED, Var: iaqi.Var})
+			}
+			continue
+		}
+
+		substitute, ok := substitutes[iaqi.Var.P]
+		switch {
+		case iaqi.SkipReason == SKIPREASON_UNSUPPORTED:
+			warnings = append(warnings, &Warning{Kind: WARNINGKIND_SKIPPED, Var: iaqi.Var})
+		case ok && given[substitute]:
+			if iaqi.SkipReason != SKIPREASON_NOT_APPLICABLE {
+				warnings = append(warnings, &Warning{Kind: WARNINGKIND_SUBSTITUTED, Var: iaqi.Var, Substitute: substitute})
+			}
+		case ok:
+			warnings = append(warnings, &Warning{Kind: WARNINGKIND_MISSING_SUBSTITUTE, Var: iaqi.Var, Substitute: substitute})
+		case iaqi.SkipReason != SKIPREASON_NOT_APPLICABLE:
+			warnings = append(warnings, &Warning{Kind: WARNINGKIND_SKIPPED, Var: iaqi.Var})
+		}
+	}
+	return warnings
+}